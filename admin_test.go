@@ -0,0 +1,72 @@
+package ravendb
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+func TestDatabaseAdmin(t *testing.T) {
+	testConfig, err := LoadTestConfig(testConfigPath)
+	require.NoError(t, err, "Failed to load test configuration")
+
+	db, cleanup := SetupTestDatabase(t, testConfig)
+	defer cleanup()
+
+	admin := NewAdmin(db)
+	name := fmt.Sprintf("AdminTest_%d", time.Now().UnixNano())
+	defer admin.DeleteDatabase(name, &interfaces.DatabaseDeleteOptions{HardDelete: true})
+
+	t.Run("Ensure Database", func(t *testing.T) {
+		created, err := admin.EnsureDatabase(&interfaces.DatabaseCreateOptions{
+			Name:     name,
+			Settings: map[string]string{"Indexing.MapTimeoutInSec": "30"},
+		})
+		require.NoError(t, err, "Failed to ensure database")
+		assert.True(t, created, "Database should be created")
+
+		created, err = admin.EnsureDatabase(&interfaces.DatabaseCreateOptions{Name: name})
+		require.NoError(t, err, "Second ensure should succeed")
+		assert.False(t, created, "Existing database should not be recreated")
+	})
+
+	t.Run("List and Exists", func(t *testing.T) {
+		names, err := admin.ListDatabases()
+		require.NoError(t, err, "Failed to list databases")
+		assert.Contains(t, names, name)
+
+		exists, err := admin.DatabaseExists(name)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = admin.DatabaseExists(name + "_missing")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Create Existing", func(t *testing.T) {
+		err := admin.CreateDatabase(&interfaces.DatabaseCreateOptions{Name: name})
+		assert.ErrorIs(t, err, interfaces.ErrDatabaseAlreadyExists)
+	})
+
+	t.Run("Disable and Enable", func(t *testing.T) {
+		require.NoError(t, admin.DisableDatabase(name), "Failed to disable database")
+		require.NoError(t, admin.EnableDatabase(name), "Failed to enable database")
+	})
+
+	t.Run("Hard Delete", func(t *testing.T) {
+		err := admin.DeleteDatabase(name, &interfaces.DatabaseDeleteOptions{
+			HardDelete:                true,
+			TimeToWaitForConfirmation: 10 * time.Second,
+		})
+		require.NoError(t, err, "Failed to delete database")
+
+		exists, err := admin.DatabaseExists(name)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
@@ -0,0 +1,126 @@
+package ravendb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+func TestDatabaseBackup(t *testing.T) {
+	testConfig, err := LoadTestConfig(testConfigPath)
+	require.NoError(t, err, "Failed to load test configuration")
+
+	// Backups cover the whole database, so use throwaway ones
+	suffix := time.Now().UnixNano()
+	config := &Config{
+		URLs:     testConfig.Database.URLs,
+		Database: fmt.Sprintf("BackupTest_%d", suffix),
+	}
+	importedName := fmt.Sprintf("BackupImported_%d", suffix)
+	restoredName := fmt.Sprintf("BackupRestored_%d", suffix)
+
+	db, err := NewDatabase(config)
+	require.NoError(t, err, "Failed to create database service")
+	defer func() {
+		admin := NewAdmin(db)
+		for _, name := range []string{config.Database, importedName, restoredName} {
+			admin.DeleteDatabase(name, &interfaces.DatabaseDeleteOptions{HardDelete: true})
+		}
+		db.Close()
+	}()
+	require.NoError(t, db.Init(), "Failed to initialize database")
+
+	users := NewCollection[TestUser](db, "Users")
+	for i := 1; i <= 3; i++ {
+		id := fmt.Sprintf("users/backup-%d", i)
+		_, err := users.Store(id, &TestUser{ID: id, Name: fmt.Sprintf("Backup User %d", i), Age: 20 + i})
+		require.NoError(t, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	dumpPath := filepath.Join(t.TempDir(), "database.ravendbdump")
+
+	t.Run("Smuggler Export", func(t *testing.T) {
+		operation, err := db.ExportDatabase(dumpPath, nil)
+		require.NoError(t, err)
+
+		reports := 0
+		state, err := operation.Wait(ctx, func(*interfaces.OperationState) { reports++ })
+		require.NoError(t, err)
+		assert.Equal(t, interfaces.OperationCompleted, state.Status)
+		assert.Equal(t, int64(3), state.Items["Documents"].Read)
+		assert.Positive(t, reports, "Progress should be reported while waiting")
+
+		info, err := os.Stat(dumpPath)
+		require.NoError(t, err)
+		assert.Positive(t, info.Size())
+
+		polled, err := db.GetOperationState(operation.ID())
+		require.NoError(t, err)
+		assert.True(t, polled.Done())
+	})
+
+	t.Run("Smuggler Import into New Database", func(t *testing.T) {
+		operation, err := db.ImportDatabase(dumpPath, &interfaces.SmugglerOptions{Database: importedName})
+		require.NoError(t, err)
+
+		_, err = operation.Wait(ctx, nil)
+		require.NoError(t, err)
+
+		imported, err := NewDatabase(&Config{URLs: config.URLs, Database: importedName})
+		require.NoError(t, err)
+		defer imported.Close()
+
+		count, err := imported.CountDocuments("Users")
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("Backup and Restore", func(t *testing.T) {
+		// The folder is on the server's file system
+		operation, err := db.BackupDatabase(&interfaces.BackupOptions{
+			FolderPath: fmt.Sprintf("/tmp/ravendb-backup-test-%d", suffix),
+		})
+		require.NoError(t, err)
+
+		state, err := operation.Wait(ctx, nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, state.BackupDirectory, "Completed backups report their directory")
+
+		operation, err = db.RestoreDatabase(&interfaces.RestoreOptions{
+			DatabaseName:   restoredName,
+			BackupLocation: state.BackupDirectory,
+		})
+		require.NoError(t, err)
+
+		_, err = operation.Wait(ctx, nil)
+		require.NoError(t, err)
+
+		exists, err := NewAdmin(db).DatabaseExists(restoredName)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("Failed Operation", func(t *testing.T) {
+		operation, err := db.RestoreDatabase(&interfaces.RestoreOptions{
+			DatabaseName:   fmt.Sprintf("BackupMissing_%d", suffix),
+			BackupLocation: "/nonexistent/backup/location",
+		})
+		if err != nil {
+			// Some servers validate the location before starting the operation
+			return
+		}
+
+		_, err = operation.Wait(ctx, nil)
+		assert.ErrorIs(t, err, interfaces.ErrOperationFailed)
+	})
+}
@@ -0,0 +1,93 @@
+package ravendb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+func TestChangesAPI(t *testing.T) {
+	testConfig, err := LoadTestConfig(testConfigPath)
+	require.NoError(t, err, "Failed to load test configuration")
+
+	db, cleanup := SetupTestDatabase(t, testConfig)
+	defer cleanup()
+
+	changes := NewChanges(db, nil)
+	defer changes.Close()
+
+	userCollection := NewCollection[TestUser](db, "Users")
+
+	// waitForEvent returns the first event for id, failing the test after a timeout
+	waitForEvent := func(t *testing.T, events <-chan interfaces.DocumentChangeEvent, id string) interfaces.DocumentChangeEvent {
+		timeout := time.After(10 * time.Second)
+		for {
+			select {
+			case event := <-events:
+				if event.ID == id {
+					return event
+				}
+			case <-timeout:
+				t.Fatalf("Timed out waiting for change event for %s", id)
+			}
+		}
+	}
+
+	t.Run("ForDocument", func(t *testing.T) {
+		sub, err := changes.ForDocument("users/changes-1")
+		require.NoError(t, err, "Failed to subscribe to document changes")
+		defer sub.Close()
+
+		_, err = userCollection.Store("users/changes-1", &TestUser{ID: "users/changes-1", Name: "Changes One", Created: time.Now()})
+		require.NoError(t, err)
+
+		event := waitForEvent(t, sub.Events(), "users/changes-1")
+		assert.Equal(t, interfaces.DocumentChangePut, event.Type)
+	})
+
+	t.Run("ForDocumentsStartingWith", func(t *testing.T) {
+		sub, err := changes.ForDocumentsStartingWith("users/changes-")
+		require.NoError(t, err, "Failed to subscribe to prefix changes")
+		defer sub.Close()
+
+		require.NoError(t, userCollection.Delete("users/changes-1"))
+
+		event := waitForEvent(t, sub.Events(), "users/changes-1")
+		assert.Equal(t, interfaces.DocumentChangeDelete, event.Type)
+	})
+
+	t.Run("ServicesShareConnection", func(t *testing.T) {
+		// Two services on the same database share the store's connection; closing one must not affect the other
+		first := NewChanges(db, nil)
+		firstSub, err := first.ForDocument("users/changes-shared")
+		require.NoError(t, err)
+		second := NewChanges(db, nil)
+		defer second.Close()
+		secondSub, err := second.ForDocument("users/changes-shared")
+		require.NoError(t, err)
+		defer secondSub.Close()
+
+		require.NoError(t, first.Close())
+		_, open := <-firstSub.Events()
+		assert.False(t, open, "Closing a service should close its subscriptions")
+
+		_, err = userCollection.Store("users/changes-shared", &TestUser{ID: "users/changes-shared", Name: "Shared", Created: time.Now()})
+		require.NoError(t, err)
+		defer userCollection.Delete("users/changes-shared")
+
+		event := waitForEvent(t, secondSub.Events(), "users/changes-shared")
+		assert.Equal(t, interfaces.DocumentChangePut, event.Type)
+	})
+
+	t.Run("CloseEndsEvents", func(t *testing.T) {
+		sub, err := changes.ForAllDocuments()
+		require.NoError(t, err)
+		require.NoError(t, sub.Close())
+
+		_, open := <-sub.Events()
+		assert.False(t, open, "Events channel should be closed after unsubscribe")
+	})
+}
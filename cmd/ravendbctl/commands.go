@@ -0,0 +1,408 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	ravendb "github.com/ternarybob/ravendb"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// queryParameters collects repeated -param name=value flags. Values are parsed as JSON when
+// possible, so numbers and booleans keep their type; anything else is a string.
+type queryParameters map[string]interface{}
+
+func (p queryParameters) String() string {
+	return fmt.Sprint(map[string]interface{}(p))
+}
+
+func (p queryParameters) Set(value string) error {
+	name, raw, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("parameter must be name=value")
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		parsed = raw
+	}
+	p[strings.TrimPrefix(name, "$")] = parsed
+	return nil
+}
+
+func runQuery(a *app, args []string) error {
+	flags := flag.NewFlagSet("query", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "Print results as JSON instead of a table")
+	parameters := queryParameters{}
+	flags.Var(parameters, "param", "Query parameter name=value, repeatable")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return newUsageError("an RQL query is required")
+	}
+
+	db, err := a.connect()
+	if err != nil {
+		return err
+	}
+	result, err := ravendb.RawQuery(db, strings.Join(flags.Args(), " "), parameters)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		return printJSON(a.stdout, result.Results)
+	}
+	printTable(a.stdout, result.Results)
+	fmt.Fprintf(a.stdout, "\n%d of %d results", len(result.Results), result.TotalResults)
+	if result.IsStale {
+		fmt.Fprint(a.stdout, " (stale)")
+	}
+	fmt.Fprintf(a.stdout, " from %s in %d ms\n", result.IndexName, result.DurationInMs)
+	return nil
+}
+
+func runGet(a *app, args []string) error {
+	flags := flag.NewFlagSet("get", flag.ContinueOnError)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return newUsageError("at least one document ID is required")
+	}
+
+	db, err := a.connect()
+	if err != nil {
+		return err
+	}
+	for _, id := range flags.Args() {
+		var document *map[string]interface{}
+		if err := db.LoadByID(id, &document); err != nil {
+			return err
+		}
+		if err := printJSON(a.stdout, document); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runPut(a *app, args []string) error {
+	flags := flag.NewFlagSet("put", flag.ContinueOnError)
+	collection := flags.String("collection", "", "Collection to store the document in")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		return newUsageError("a document ID and at most one file are required")
+	}
+	id := flags.Arg(0)
+
+	input := a.stdin
+	if flags.NArg() == 2 && flags.Arg(1) != "-" {
+		file, err := os.Open(flags.Arg(1))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	document, err := readDocument(input, *collection)
+	if err != nil {
+		return err
+	}
+
+	db, err := a.connect()
+	if err != nil {
+		return err
+	}
+	result, err := db.Store(id, document)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Stored %s (change vector %s)\n", result.ID, result.ChangeVector)
+	return nil
+}
+
+// readDocument decodes a JSON document, keeping numbers exact, and sets its collection if given
+func readDocument(input io.Reader, collection string) (map[string]interface{}, error) {
+	var document map[string]interface{}
+	decoder := json.NewDecoder(input)
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+	if document == nil {
+		return nil, fmt.Errorf("failed to parse document: not a JSON object")
+	}
+
+	// Map documents are stored as-is, so the collection must be set in their metadata
+	if collection != "" {
+		metadata, _ := document["@metadata"].(map[string]interface{})
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		metadata["@collection"] = collection
+		document["@metadata"] = metadata
+	}
+	return document, nil
+}
+
+func runDelete(a *app, args []string) error {
+	flags := flag.NewFlagSet("delete", flag.ContinueOnError)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return newUsageError("at least one document ID is required")
+	}
+
+	db, err := a.connect()
+	if err != nil {
+		return err
+	}
+	deleted, err := db.DeleteMultipleWithOptions(flags.Args(), nil)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Deleted %d of %d documents\n", len(deleted), flags.NArg())
+	return nil
+}
+
+func runStats(a *app, args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "Print statistics as JSON")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return newUsageError("at most one collection is allowed")
+	}
+
+	db, err := a.connect()
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() == 1 {
+		collection := flags.Arg(0)
+		count, err := ravendb.NewCollection[map[string]interface{}](db, collection).Count()
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, map[string]interface{}{"collection": collection, "documentCount": count})
+		}
+		fmt.Fprintf(a.stdout, "%s: %d documents\n", collection, count)
+		return nil
+	}
+
+	status, err := db.GetStatus()
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(a.stdout, status)
+	}
+	if status.Error != "" {
+		return fmt.Errorf("database %s is %s: %s", status.DatabaseName, status.Status, status.Error)
+	}
+
+	printStatus(a.stdout, status)
+	return nil
+}
+
+func runExport(a *app, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	collections := flags.String("collections", "", "Comma-separated collections, default all")
+	where := flags.String("where", "", "RQL where clause applied to each collection")
+	attachments := flags.Bool("attachments", false, "Include attachment contents")
+	counters := flags.Bool("counters", false, "Include counter values")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return newUsageError("an output file is required")
+	}
+
+	db, err := a.connect()
+	if err != nil {
+		return err
+	}
+	result, err := ravendb.ExportToFile(db, flags.Arg(0), &interfaces.ExportOptions{
+		Collections:        splitList(*collections),
+		Where:              *where,
+		IncludeAttachments: *attachments,
+		IncludeCounters:    *counters,
+		Progress:           progressPrinter(a.stderr, "Exported"),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Exported %d documents, %d attachments and %d counters in %s\n",
+		result.Documents, result.Attachments, result.Counters, result.Duration.Round(time.Millisecond))
+	return nil
+}
+
+func runImport(a *app, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	collections := flags.String("collections", "", "Comma-separated collections to import, default all")
+	batchSize := flags.Int("batch", 0, "Documents per bulk insert (default 1000)")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return newUsageError("an input file is required")
+	}
+
+	db, err := a.connect()
+	if err != nil {
+		return err
+	}
+	result, err := ravendb.ImportFromFile(db, flags.Arg(0), &interfaces.ImportOptions{
+		Collections: splitList(*collections),
+		BatchSize:   *batchSize,
+		Progress:    progressPrinter(a.stderr, "Imported"),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Imported %d documents, %d attachments and %d counters in %s",
+		result.Documents, result.Attachments, result.Counters, result.Duration.Round(time.Millisecond))
+	if result.Skipped > 0 {
+		fmt.Fprintf(a.stdout, ", skipped %d", result.Skipped)
+	}
+	fmt.Fprintln(a.stdout)
+	return nil
+}
+
+// progressPrinter reports transfer progress on standard error, keeping standard output clean
+func progressPrinter(w io.Writer, verb string) func(interfaces.TransferProgress) {
+	return func(p interfaces.TransferProgress) {
+		if !p.Completed {
+			fmt.Fprintf(w, "%s %d documents (%s)\n", verb, p.Documents, p.Collection)
+		}
+	}
+}
+
+func runIndex(a *app, args []string) error {
+	if len(args) == 0 {
+		return newUsageError("a subcommand is required")
+	}
+
+	switch args[0] {
+	case "list":
+		flags := flag.NewFlagSet("index list", flag.ContinueOnError)
+		asJSON := flags.Bool("json", false, "Print indexes as JSON")
+		if err := parseFlags(flags, args[1:]); err != nil {
+			return err
+		}
+
+		db, err := a.connect()
+		if err != nil {
+			return err
+		}
+		indexes, err := db.GetIndexes()
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, indexes)
+		}
+		printIndexes(a.stdout, indexes)
+		return nil
+
+	case "reset":
+		if len(args) != 2 {
+			return newUsageError("an index name is required")
+		}
+
+		db, err := a.connect()
+		if err != nil {
+			return err
+		}
+		if err := db.ResetIndex(args[1]); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "Reset index %s\n", args[1])
+		return nil
+	}
+
+	return newUsageError("unknown subcommand %q", args[0])
+}
+
+func runDB(a *app, args []string) error {
+	if len(args) == 0 {
+		return newUsageError("a subcommand is required")
+	}
+
+	switch args[0] {
+	case "create":
+		flags := flag.NewFlagSet("db create", flag.ContinueOnError)
+		replication := flags.Int("replication", 1, "Replication factor")
+		if err := parseFlags(flags, args[1:]); err != nil {
+			return err
+		}
+		if flags.NArg() != 1 {
+			return newUsageError("a database name is required")
+		}
+
+		db, err := a.connect()
+		if err != nil {
+			return err
+		}
+		err = ravendb.NewAdmin(db).CreateDatabase(&interfaces.DatabaseCreateOptions{
+			Name:              flags.Arg(0),
+			ReplicationFactor: *replication,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "Created database %s\n", flags.Arg(0))
+		return nil
+
+	case "delete":
+		flags := flag.NewFlagSet("db delete", flag.ContinueOnError)
+		hard := flags.Bool("hard", false, "Also delete the data files")
+		yes := flags.Bool("yes", false, "Confirm that the database should be deleted")
+		if err := parseFlags(flags, args[1:]); err != nil {
+			return err
+		}
+		if flags.NArg() != 1 {
+			return newUsageError("a database name is required")
+		}
+		if !*yes {
+			return newUsageError("deleting database %s requires -yes", flags.Arg(0))
+		}
+
+		db, err := a.connect()
+		if err != nil {
+			return err
+		}
+		err = ravendb.NewAdmin(db).DeleteDatabase(flags.Arg(0), &interfaces.DatabaseDeleteOptions{HardDelete: *hard})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "Deleted database %s\n", flags.Arg(0))
+		return nil
+	}
+
+	return newUsageError("unknown subcommand %q", args[0])
+}
+
+// printJSON writes a value as indented JSON
+func printJSON(w io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(value)
+}
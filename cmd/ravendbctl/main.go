@@ -0,0 +1,218 @@
+// Command ravendbctl runs everyday RavenDB tasks from the command line: ad-hoc RQL queries,
+// document reads and writes, statistics, collection export and import, index maintenance and
+// database administration.
+//
+// Connection settings are read from a TOML file in the same layout as config/*.toml:
+//
+//	[database]
+//	urls = ["http://localhost:8080"]
+//	database = "Northwind"
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	ravendb "github.com/ternarybob/ravendb"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// defaultConfigPath is used when neither -config nor RAVENDB_CONFIG is set
+const defaultConfigPath = "config/local_config.toml"
+
+// fileConfig is the layout of config/*.toml; sections other than [database] are ignored
+type fileConfig struct {
+	Database struct {
+		URLs     []string `toml:"urls"`
+		Database string   `toml:"database"`
+	} `toml:"database"`
+}
+
+// command is a subcommand; run receives the arguments that follow its name
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(app *app, args []string) error
+}
+
+var commands = []*command{
+	{"query", "query [-json] [-param name=value]... RQL", "Run an RQL query and print the results as a table or JSON", runQuery},
+	{"get", "get ID...", "Print documents as JSON", runGet},
+	{"put", "put [-collection NAME] ID [FILE]", "Store a JSON document read from FILE or standard input", runPut},
+	{"delete", "delete ID...", "Delete documents", runDelete},
+	{"stats", "stats [-json] [COLLECTION]", "Print database statistics, or the document count of a collection", runStats},
+	{"export", "export [-collections A,B] [-where RQL] [-attachments] [-counters] FILE", "Export collections to an NDJSON file", runExport},
+	{"import", "import [-collections A,B] [-batch N] FILE", "Import an NDJSON file written by export", runImport},
+	{"index", "index list [-json] | index reset NAME", "List indexes or rebuild one", runIndex},
+	{"db", "db create [-replication N] NAME | db delete -yes [-hard] NAME", "Create or delete a database", runDB},
+}
+
+// usageError is reported with the command's usage line and exit status 2
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+func newUsageError(format string, args ...interface{}) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// app holds the global options and the lazily opened database connection
+type app struct {
+	configPath string
+	urls       string
+	database   string
+	stdout     io.Writer
+	stderr     io.Writer
+	stdin      io.Reader
+
+	db interfaces.IRavenDBService
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run parses the global flags, dispatches to the subcommand and returns the exit status
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	a := &app{stdout: stdout, stderr: stderr, stdin: stdin}
+
+	flags := flag.NewFlagSet("ravendbctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&a.configPath, "config", os.Getenv("RAVENDB_CONFIG"), "TOML connection settings (default $RAVENDB_CONFIG or "+defaultConfigPath+")")
+	flags.StringVar(&a.urls, "urls", "", "Comma-separated server URLs, overriding the config file")
+	flags.StringVar(&a.database, "database", "", "Database name, overriding the config file")
+	flags.Usage = func() { printUsage(flags) }
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		printUsage(flags)
+		return 2
+	}
+
+	name := flags.Arg(0)
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+
+		err := cmd.run(a, flags.Args()[1:])
+		if a.db != nil {
+			a.db.Close()
+		}
+
+		var usageErr *usageError
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			fmt.Fprintf(a.stdout, "usage: ravendbctl %s\n", cmd.usage)
+			return 0
+		case errors.As(err, &usageErr):
+			fmt.Fprintf(a.stderr, "ravendbctl %s: %v\nusage: ravendbctl %s\n", name, err, cmd.usage)
+			return 2
+		default:
+			fmt.Fprintf(a.stderr, "ravendbctl %s: %v\n", name, err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(a.stderr, "ravendbctl: unknown command %q\n", name)
+	printUsage(flags)
+	return 2
+}
+
+func printUsage(flags *flag.FlagSet) {
+	out := flags.Output()
+	fmt.Fprintln(out, "usage: ravendbctl [-config FILE] [-urls URLS] [-database NAME] COMMAND [ARGS]")
+	fmt.Fprintln(out, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-8s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(out, "\nGlobal flags:")
+	flags.PrintDefaults()
+}
+
+// config builds the connection configuration from the config file and flag overrides.
+// The config file is optional when -urls and -database are both given.
+func (a *app) config() (*ravendb.Config, error) {
+	var file fileConfig
+
+	path := a.configPath
+	if path == "" {
+		path = defaultConfigPath
+	}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := toml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	case a.configPath != "" || a.urls == "" || a.database == "":
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	config := ravendb.NewConfig(file.Database.URLs, file.Database.Database)
+	if a.urls != "" {
+		config.URLs = splitList(a.urls)
+	}
+	if a.database != "" {
+		config.Database = a.database
+	}
+	if len(config.URLs) == 0 || config.Database == "" {
+		return nil, fmt.Errorf("server URLs and database name are required")
+	}
+	return config, nil
+}
+
+// connect opens the database connection on first use
+func (a *app) connect() (interfaces.IRavenDBService, error) {
+	if a.db != nil {
+		return a.db, nil
+	}
+
+	config, err := a.config()
+	if err != nil {
+		return nil, err
+	}
+	db, err := ravendb.NewDatabase(config)
+	if err != nil {
+		return nil, err
+	}
+	a.db = db
+	return db, nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseFlags parses a subcommand's flags, reporting problems as usage errors
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	flags.SetOutput(io.Discard)
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return newUsageError("%v", err)
+	}
+	return nil
+}
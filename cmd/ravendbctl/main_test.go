@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runCommand runs the tool with the given arguments and returns its exit status and output
+func runCommand(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun(t *testing.T) {
+	t.Run("No Command", func(t *testing.T) {
+		code, stdout, stderr := runCommand("")
+		assert.Equal(t, 2, code)
+		assert.Empty(t, stdout)
+		assert.Contains(t, stderr, "usage: ravendbctl")
+		assert.Contains(t, stderr, "Commands:")
+	})
+
+	t.Run("Unknown Command", func(t *testing.T) {
+		code, _, stderr := runCommand("", "frobnicate")
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr, `unknown command "frobnicate"`)
+	})
+
+	t.Run("Unknown Global Flag", func(t *testing.T) {
+		code, _, stderr := runCommand("", "-bogus", "get", "users/1")
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr, "-bogus")
+	})
+
+	t.Run("Subcommand Help", func(t *testing.T) {
+		code, stdout, stderr := runCommand("", "query", "-h")
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout, "usage: ravendbctl query")
+		assert.Empty(t, stderr)
+	})
+
+	t.Run("Usage Errors", func(t *testing.T) {
+		cases := []struct {
+			args    []string
+			message string
+		}{
+			{[]string{"query"}, "an RQL query is required"},
+			{[]string{"query", "-param", "novalue", "from Users"}, "parameter must be name=value"},
+			{[]string{"get"}, "at least one document ID is required"},
+			{[]string{"get", "-bogus", "users/1"}, "-bogus"},
+			{[]string{"put"}, "a document ID and at most one file are required"},
+			{[]string{"put", "users/1", "a.json", "b.json"}, "a document ID and at most one file are required"},
+			{[]string{"delete"}, "at least one document ID is required"},
+			{[]string{"stats", "Users", "Orders"}, "at most one collection is allowed"},
+			{[]string{"export"}, "an output file is required"},
+			{[]string{"import"}, "an input file is required"},
+			{[]string{"index"}, "a subcommand is required"},
+			{[]string{"index", "drop"}, `unknown subcommand "drop"`},
+			{[]string{"index", "reset"}, "an index name is required"},
+			{[]string{"db", "create"}, "a database name is required"},
+			{[]string{"db", "delete", "Staging"}, "deleting database Staging requires -yes"},
+			{[]string{"db", "delete", "-hard", "Staging"}, "deleting database Staging requires -yes"},
+		}
+		for _, c := range cases {
+			code, stdout, stderr := runCommand("", c.args...)
+			assert.Equal(t, 2, code, "%v should be a usage error", c.args)
+			assert.Empty(t, stdout, "%v", c.args)
+			assert.Contains(t, stderr, c.message, "%v", c.args)
+			assert.Contains(t, stderr, "usage: ravendbctl "+c.args[0], "%v", c.args)
+		}
+	})
+
+	t.Run("Missing Config File", func(t *testing.T) {
+		missing := filepath.Join(t.TempDir(), "missing.toml")
+		code, stdout, stderr := runCommand("", "-config", missing, "get", "users/1")
+		assert.Equal(t, 1, code)
+		assert.Empty(t, stdout)
+		assert.Contains(t, stderr, "failed to read config file")
+	})
+
+	t.Run("Invalid Document", func(t *testing.T) {
+		code, _, stderr := runCommand("not json", "-urls", "http://127.0.0.1:1", "-database", "Test", "put", "users/1")
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, "failed to parse document")
+	})
+}
+
+func TestQueryParameters(t *testing.T) {
+	parameters := queryParameters{}
+	require.NoError(t, parameters.Set("min=30"))
+	require.NoError(t, parameters.Set("$active=true"))
+	require.NoError(t, parameters.Set("name=Ada"))
+	require.NoError(t, parameters.Set(`quoted="42"`))
+	require.NoError(t, parameters.Set("tags=[\"a\",\"b\"]"))
+	require.NoError(t, parameters.Set("empty="))
+
+	assert.Equal(t, float64(30), parameters["min"], "Numbers keep their type")
+	assert.Equal(t, true, parameters["active"], "A $ prefix is stripped")
+	assert.Equal(t, "Ada", parameters["name"], "Non-JSON values are strings")
+	assert.Equal(t, "42", parameters["quoted"], "JSON strings are unquoted")
+	assert.Equal(t, []interface{}{"a", "b"}, parameters["tags"])
+	assert.Equal(t, "", parameters["empty"])
+
+	assert.Error(t, parameters.Set("novalue"), "A missing = is rejected")
+	assert.Error(t, parameters.Set("=30"), "An empty name is rejected")
+}
+
+func TestReadDocument(t *testing.T) {
+	t.Run("Sets Collection", func(t *testing.T) {
+		document, err := readDocument(strings.NewReader(`{"name": "Ada", "@metadata": {"tenant": "acme"}}`), "Users")
+		require.NoError(t, err)
+
+		metadata, ok := document["@metadata"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, "Users", metadata["@collection"])
+		assert.Equal(t, "acme", metadata["tenant"], "Existing metadata is kept")
+	})
+
+	t.Run("Adds Metadata", func(t *testing.T) {
+		document, err := readDocument(strings.NewReader(`{"name": "Ada"}`), "Users")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"@collection": "Users"}, document["@metadata"])
+	})
+
+	t.Run("Without Collection", func(t *testing.T) {
+		document, err := readDocument(strings.NewReader(`{"name": "Ada", "age": 12345678901234567890}`), "")
+		require.NoError(t, err)
+		assert.NotContains(t, document, "@metadata")
+		assert.Equal(t, json.Number("12345678901234567890"), document["age"], "Numbers are kept exact")
+	})
+
+	t.Run("Rejects Non-Objects", func(t *testing.T) {
+		_, err := readDocument(strings.NewReader(`[1, 2]`), "")
+		assert.Error(t, err)
+		_, err = readDocument(strings.NewReader(`null`), "")
+		assert.Error(t, err)
+	})
+}
+
+func TestConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte(
+		"[database]\nurls = [\"http://localhost:8080\"]\ndatabase = \"FromFile\"\n\n[logging]\nlevel = \"info\"\n"), 0o644))
+
+	t.Run("From File", func(t *testing.T) {
+		config, err := (&app{configPath: path}).config()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"http://localhost:8080"}, config.URLs)
+		assert.Equal(t, "FromFile", config.Database)
+	})
+
+	t.Run("Flag Overrides", func(t *testing.T) {
+		config, err := (&app{configPath: path, urls: "http://a:8080, http://b:8080,", database: "Staging"}).config()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"http://a:8080", "http://b:8080"}, config.URLs)
+		assert.Equal(t, "Staging", config.Database)
+	})
+
+	t.Run("Flags Without File", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		config, err := (&app{urls: "http://localhost:8080", database: "Test"}).config()
+		require.NoError(t, err)
+		assert.Equal(t, "Test", config.Database)
+
+		_, err = (&app{urls: "http://localhost:8080"}).config()
+		assert.Error(t, err, "The default config file is required unless both flags are given")
+	})
+}
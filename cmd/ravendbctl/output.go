@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// maxCellWidth truncates long values so that tables stay readable
+const maxCellWidth = 40
+
+// printTable writes query results as a table with the document ID first and the remaining
+// top-level fields in name order
+func printTable(w io.Writer, results []map[string]interface{}) {
+	if len(results) == 0 {
+		fmt.Fprintln(w, "No results")
+		return
+	}
+
+	fields := make(map[string]bool)
+	for _, result := range results {
+		for field := range result {
+			if field != "@metadata" {
+				fields[field] = true
+			}
+		}
+	}
+	columns := make([]string, 0, len(fields))
+	for field := range fields {
+		columns = append(columns, field)
+	}
+	sort.Strings(columns)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "@id\t"+strings.Join(columns, "\t"))
+	for _, result := range results {
+		metadata, _ := result["@metadata"].(map[string]interface{})
+		id, _ := metadata["@id"].(string)
+
+		cells := []string{id}
+		for _, column := range columns {
+			value, ok := result[column]
+			if !ok {
+				cells = append(cells, "")
+				continue
+			}
+			cells = append(cells, formatCell(value))
+		}
+		fmt.Fprintln(table, strings.Join(cells, "\t"))
+	}
+	table.Flush()
+}
+
+// formatCell renders a value on one line: strings as-is, everything else as JSON
+func formatCell(value interface{}) string {
+	var text string
+	if s, ok := value.(string); ok {
+		text = s
+	} else {
+		data, err := json.Marshal(value)
+		if err != nil {
+			data = []byte(fmt.Sprint(value))
+		}
+		text = string(data)
+	}
+
+	text = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(text)
+	if runes := []rune(text); len(runes) > maxCellWidth {
+		text = string(runes[:maxCellWidth-3]) + "..."
+	}
+	return text
+}
+
+// printStatus writes database statistics followed by the per-collection document counts
+func printStatus(w io.Writer, status *interfaces.DatabaseStatus) {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "Database\t%s (%s)\n", status.DatabaseName, status.Status)
+	fmt.Fprintf(table, "Documents\t%d\n", status.DocumentCount)
+	fmt.Fprintf(table, "Indexes\t%d (%d stale)\n", status.IndexCount, len(status.StaleIndexes))
+	fmt.Fprintf(table, "Attachments\t%d\n", status.AttachmentCount)
+	fmt.Fprintf(table, "Counters\t%d\n", status.CounterCount)
+	fmt.Fprintf(table, "Revisions\t%d\n", status.RevisionCount)
+	fmt.Fprintf(table, "Size on disk\t%s\n", status.SizeOnDiskHumane)
+	table.Flush()
+
+	if len(status.Collections) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(status.Collections))
+	for name := range status.Collections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w)
+	table = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "Collection\tDocuments")
+	for _, name := range names {
+		fmt.Fprintf(table, "%s\t%d\n", name, status.Collections[name])
+	}
+	table.Flush()
+}
+
+// printIndexes writes one line per index
+func printIndexes(w io.Writer, indexes []interfaces.IndexInfo) {
+	if len(indexes) == 0 {
+		fmt.Fprintln(w, "No indexes")
+		return
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "Name\tType\tState\tStale\tEntries\tErrors\tLast indexed")
+	for _, index := range indexes {
+		lastIndexed := "-"
+		if index.LastIndexingTime != nil {
+			lastIndexed = index.LastIndexingTime.Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%t\t%d\t%d\t%s\n",
+			index.Name, index.Type, index.State, index.IsStale, index.EntriesCount, index.ErrorsCount, lastIndexed)
+	}
+	table.Flush()
+}
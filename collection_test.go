@@ -1,14 +1,120 @@
 package ravendb
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	ravendbclient "github.com/ravendb/ravendb-go-client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/ternarybob/ravendb/interfaces"
 )
 
+// taggedUser marks its identity field with the ravendb tag instead of naming it ID
+type taggedUser struct {
+	Key  string `json:"key" ravendb:"id"`
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+// TestCategory takes the pluralized type name as its collection
+type TestCategory struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// auditEntry names its collection through a ravendb tag option
+type auditEntry struct {
+	ID     string `json:"id" ravendb:"id,collection=AuditLog"`
+	Action string `json:"action"`
+}
+
+// TestOrder names its collection through a CollectionName method
+type TestOrder struct {
+	ID    string  `json:"id"`
+	Total float64 `json:"total"`
+}
+
+func (TestOrder) CollectionName() string { return "Orders" }
+
+func TestDerivedCollectionNames(t *testing.T) {
+	assert.Equal(t, "TestCategories", CollectionName[TestCategory]())
+	assert.Equal(t, "AuditLog", CollectionName[auditEntry]())
+	assert.Equal(t, "Orders", CollectionName[TestOrder]())
+}
+
+// validatedUser checks its own fields before it is stored
+type validatedUser struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Age   int    `json:"age"`
+}
+
+func (u validatedUser) Validate() error {
+	var errs []error
+	if u.Name == "" {
+		errs = append(errs, &interfaces.FieldError{Field: "name", Message: "is required"})
+	}
+	if u.Age < 0 {
+		errs = append(errs, &interfaces.FieldError{Field: "age", Message: "must not be negative"})
+	}
+	return errors.Join(errs...)
+}
+
+func TestValidation(t *testing.T) {
+	// Validation fails before the server is contacted, so an unreachable node is enough
+	db, err := NewDatabase(NewSingleNodeConfig("http://127.0.0.1:1", "ValidationTest"))
+	require.NoError(t, err, "Failed to create database service")
+	defer db.Close()
+
+	users := NewCollectionWithOptions[validatedUser](db, "Users", &interfaces.CollectionOptions{
+		Validators: []func(document interface{}) error{
+			func(document interface{}) error {
+				if !strings.Contains(document.(*validatedUser).Email, "@") {
+					return &interfaces.FieldError{Field: "email", Message: "must be an email address"}
+				}
+				return nil
+			},
+		},
+	})
+
+	t.Run("Store Lists Invalid Fields", func(t *testing.T) {
+		_, err := users.Store("users/invalid-1", &validatedUser{Age: -1, Email: "nope"})
+		require.ErrorIs(t, err, interfaces.ErrValidation)
+
+		var validationErr *interfaces.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "users/invalid-1", validationErr.ID)
+		assert.Equal(t, "Users", validationErr.Collection)
+		assert.Equal(t, []interfaces.FieldError{
+			{Field: "name", Message: "is required"},
+			{Field: "age", Message: "must not be negative"},
+			{Field: "email", Message: "must be an email address"},
+		}, validationErr.Fields)
+	})
+
+	t.Run("StoreMultiple Rejects the Batch", func(t *testing.T) {
+		_, err := users.StoreMultiple(map[string]*validatedUser{
+			"users/invalid-2": {ID: "users/invalid-2", Name: "Valid", Email: "valid@example.com"},
+			"users/invalid-3": {ID: "users/invalid-3", Name: "No Email"},
+		})
+		require.ErrorIs(t, err, interfaces.ErrValidation)
+		assert.Contains(t, err.Error(), "users/invalid-3")
+		assert.NotContains(t, err.Error(), "users/invalid-2")
+	})
+
+	t.Run("Update Validates", func(t *testing.T) {
+		err := users.Update("", &validatedUser{ID: "users/invalid-4", Email: "a@example.com"})
+		var validationErr *interfaces.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "users/invalid-4", validationErr.ID, "ID should come from the document")
+		assert.Len(t, validationErr.Fields, 1)
+	})
+}
+
 func TestCollectionService(t *testing.T) {
 	testConfig, err := LoadTestConfig(testConfigPath)
 	require.NoError(t, err, "Failed to load test configuration")
@@ -30,7 +136,7 @@ func TestCollectionService(t *testing.T) {
 		}
 
 		// Store document
-		err := userCollection.Store("users/typed-1", user)
+		_, err := userCollection.Store("users/typed-1", &user)
 		assert.NoError(t, err, "Failed to store typed document")
 
 		// Load document
@@ -47,7 +153,7 @@ func TestCollectionService(t *testing.T) {
 	})
 
 	t.Run("StoreMultipleTypedDocuments", func(t *testing.T) {
-		users := map[string]TestUser{
+		users := map[string]*TestUser{
 			"users/typed-2": {
 				ID:       "users/typed-2",
 				Name:     "Bob Wilson",
@@ -74,7 +180,7 @@ func TestCollectionService(t *testing.T) {
 			},
 		}
 
-		err := userCollection.StoreMultiple(users)
+		_, err := userCollection.StoreMultiple(users)
 		assert.NoError(t, err, "Failed to store multiple typed documents")
 
 		// Verify documents exist
@@ -102,6 +208,16 @@ func TestCollectionService(t *testing.T) {
 		assert.Contains(t, userMap, "users/typed-4")
 	})
 
+	t.Run("LoadMultipleByIDsWithMissing", func(t *testing.T) {
+		result, err := userCollection.LoadMultipleByIDsWithMissing([]string{"users/typed-4", "users/missing-1", "users/typed-2", "users/missing-2"})
+		require.NoError(t, err)
+
+		require.Len(t, result.Results, 2)
+		assert.Equal(t, "users/typed-4", result.Results[0].ID, "Results keep the requested order")
+		assert.Equal(t, "users/typed-2", result.Results[1].ID)
+		assert.Equal(t, []string{"users/missing-1", "users/missing-2"}, result.Missing)
+	})
+
 	t.Run("UpdateTypedDocument", func(t *testing.T) {
 		// Load existing document
 		user, err := userCollection.LoadByID("users/typed-2")
@@ -113,7 +229,7 @@ func TestCollectionService(t *testing.T) {
 		user.IsActive = false
 
 		// Update document
-		err = userCollection.Update("users/typed-2", *user)
+		err = userCollection.Update("users/typed-2", user)
 		assert.NoError(t, err, "Failed to update typed document")
 
 		// Load and verify
@@ -210,6 +326,251 @@ func TestCollectionService(t *testing.T) {
 		count, err := userCollection.Count()
 		assert.NoError(t, err, "Failed to count documents")
 		assert.GreaterOrEqual(t, count, 4, "Should have at least 4 users")
+
+		// The database service counts the same named collection from its statistics
+		dbCount, err := db.CountDocuments("Users")
+		assert.NoError(t, err, "Failed to count collection documents")
+		assert.Equal(t, count, dbCount)
+
+		// Filtered counts run a count query against the collection
+		filtered, err := userCollection.CountWithOptions(&interfaces.QueryOptions{
+			WhereClause: "age >= $minAge",
+			Parameters:  map[string]interface{}{"minAge": 40},
+		})
+		assert.NoError(t, err, "Failed to count filtered documents")
+		assert.GreaterOrEqual(t, filtered, 1, "David should match the filter")
+		assert.Less(t, filtered, count, "Filter should exclude younger users")
+	})
+
+	t.Run("IDStrategies", func(t *testing.T) {
+		storeWith := func(t *testing.T, options *interfaces.CollectionOptions) string {
+			collection := NewCollectionWithOptions[TestUser](db, "Users", options)
+			user := TestUser{Name: "Strategy User"}
+			result, err := collection.Store("", &user)
+			require.NoError(t, err)
+			t.Cleanup(func() { collection.Delete(result.ID) })
+			assert.Equal(t, result.ID, user.ID)
+			return result.ID
+		}
+
+		assert.Regexp(t, `^users/\d+-[A-Z]+$`, storeWith(t, nil), "HiLo is the default")
+		assert.Regexp(t, `^users/\d+$`, storeWith(t, &interfaces.CollectionOptions{IDStrategy: interfaces.IDStrategyIdentity}))
+		assert.Regexp(t, `^users/[0-9a-f-]{36}$`, storeWith(t, &interfaces.CollectionOptions{IDStrategy: interfaces.IDStrategyGUID}))
+
+		first := storeWith(t, &interfaces.CollectionOptions{IDStrategy: interfaces.IDStrategyULID})
+		time.Sleep(2 * time.Millisecond)
+		second := storeWith(t, &interfaces.CollectionOptions{IDStrategy: interfaces.IDStrategyULID})
+		assert.Regexp(t, `^users/[0-9A-HJKMNP-TV-Z]{26}$`, first)
+		assert.Less(t, first, second, "ULIDs sort by creation time")
+
+		custom := storeWith(t, &interfaces.CollectionOptions{
+			IDGenerator: func(document interface{}) (string, error) {
+				return "users/" + strings.ToLower(strings.ReplaceAll(document.(*TestUser).Name, " ", "-")), nil
+			},
+		})
+		assert.Equal(t, "users/strategy-user", custom)
+	})
+
+	t.Run("TaggedIDField", func(t *testing.T) {
+		tagged := NewCollection[taggedUser](db, "Users")
+		defer tagged.DeleteMultiple([]string{"users/tagged-1"})
+
+		result, err := tagged.Store("", &taggedUser{Key: "users/tagged-1", Name: "Tagged", Age: 30})
+		require.NoError(t, err, "Store should take the ID from the tagged field")
+		assert.Equal(t, "users/tagged-1", result.ID)
+
+		generated := taggedUser{Name: "Tagged Generated"}
+		result, err = tagged.Store("", &generated)
+		require.NoError(t, err)
+		defer tagged.Delete(result.ID)
+		assert.Equal(t, result.ID, generated.Key, "Generated ID should be written into the tagged field")
+
+		loaded, err := tagged.LoadByID("users/tagged-1")
+		require.NoError(t, err)
+		assert.Equal(t, "users/tagged-1", loaded.Key)
+
+		many, err := tagged.LoadMultipleByIDs([]string{"users/tagged-1", result.ID})
+		require.NoError(t, err)
+		require.Len(t, many, 2)
+		assert.Equal(t, "users/tagged-1", many[0].Key)
+		assert.Equal(t, result.ID, many[1].Key)
+
+		loaded.Age = 31
+		require.NoError(t, tagged.Update("", loaded), "Update should take the ID from the tagged field")
+
+		queried, err := tagged.QueryByField("name", "Tagged", nil)
+		require.NoError(t, err)
+		require.Len(t, queried.Results, 1)
+		assert.Equal(t, "users/tagged-1", queried.Results[0].Key)
+		assert.Equal(t, 31, queried.Results[0].Age)
+	})
+
+	t.Run("DerivedCollection", func(t *testing.T) {
+		orders := NewCollectionFor[TestOrder](db, nil)
+		defer orders.DeleteMultiple([]string{"orders/derived-1", "orders/derived-2"})
+
+		_, err := orders.Store("orders/derived-1", &TestOrder{ID: "orders/derived-1", Total: 10})
+		require.NoError(t, err)
+		// Stores through the database service use the same derived name
+		_, err = db.Store("orders/derived-2", &TestOrder{ID: "orders/derived-2", Total: 20})
+		require.NoError(t, err)
+
+		count, err := db.CountDocuments("Orders")
+		require.NoError(t, err)
+		assert.Equal(t, 2, count, "Both documents should be in the derived collection")
+
+		all, err := orders.QueryAll()
+		require.NoError(t, err)
+		assert.Len(t, all.Results, 2, "Queries should read only the derived collection")
+
+		generic, err := QueryAll[TestOrder](db, "")
+		require.NoError(t, err)
+		assert.Len(t, generic.Results, 2)
+	})
+
+	t.Run("SharedDocumentType", func(t *testing.T) {
+		// Services sharing a type each store into their own collection, whichever was created last
+		admins := NewCollection[TestUser](db, "Admins")
+		members := NewCollection[TestUser](db, "Members")
+
+		admin, err := admins.Store("", &TestUser{Name: "Admin"})
+		require.NoError(t, err)
+		defer admins.Delete(admin.ID)
+		assert.Regexp(t, `^admins/\d+-[A-Z]+$`, admin.ID, "HiLo IDs use the collection's prefix")
+
+		member, err := members.Store("members/shared-1", &TestUser{Name: "Member"})
+		require.NoError(t, err)
+		defer members.Delete(member.ID)
+		require.NoError(t, members.Update(member.ID, &TestUser{Name: "Member Updated"}))
+
+		loaded, err := admins.LoadWithMetadata(admin.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Admins", loaded.Metadata.Collection)
+
+		loaded, err = members.LoadWithMetadata(member.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Members", loaded.Metadata.Collection)
+		assert.Equal(t, "Member Updated", loaded.Document.Name)
+
+		count, err := admins.Count()
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Metadata", func(t *testing.T) {
+		defer userCollection.Delete("users/metadata-1")
+
+		user := TestUser{ID: "users/metadata-1", Name: "Meta", Age: 40}
+		stored, err := userCollection.StoreWithOptions("users/metadata-1", &user, &interfaces.StoreOptions{
+			Metadata: map[string]interface{}{"tenantId": "acme", "schemaVersion": 2},
+		})
+		require.NoError(t, err, "Failed to store document with custom metadata")
+
+		loaded, err := userCollection.LoadWithMetadata("users/metadata-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Meta", loaded.Document.Name)
+		assert.Equal(t, "users/metadata-1", loaded.Metadata.ID)
+		assert.Equal(t, "Users", loaded.Metadata.Collection)
+		assert.Equal(t, stored.ChangeVector, loaded.Metadata.ChangeVector)
+		assert.False(t, loaded.Metadata.LastModified.IsZero())
+		assert.Equal(t, "acme", loaded.Metadata.Custom["tenantId"])
+		assert.EqualValues(t, 2, loaded.Metadata.Custom["schemaVersion"])
+		assert.Len(t, loaded.Metadata.Custom, 2, "Custom should hold only the user's entries")
+		assert.NotContains(t, loaded.Metadata.Custom, ravendbclient.MetadataRavenGoType)
+
+		user.Age = 41
+		require.NoError(t, userCollection.Update("users/metadata-1", &user))
+		many, err := userCollection.LoadMultipleWithMetadata([]string{"users/metadata-1", "users/metadata-missing"})
+		require.NoError(t, err)
+		require.Len(t, many.Results, 1)
+		assert.Equal(t, 41, many.Results[0].Document.Age)
+		assert.Equal(t, "acme", many.Results[0].Metadata.Custom["tenantId"], "Update should keep custom metadata")
+		assert.Len(t, many.Results[0].Metadata.Custom, 2)
+		assert.Equal(t, []string{"users/metadata-missing"}, many.Missing)
+
+		_, err = userCollection.LoadWithMetadata("users/metadata-missing")
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+
+		_, err = userCollection.StoreWithOptions("users/metadata-1", &user, &interfaces.StoreOptions{
+			Metadata: map[string]interface{}{"@collection": "Other"},
+		})
+		assert.Error(t, err, "Reserved metadata keys should be rejected")
+	})
+
+	t.Run("CreateReplaceUpsert", func(t *testing.T) {
+		defer userCollection.DeleteMultiple([]string{"users/create-1", "users/upsert-1"})
+
+		user := TestUser{ID: "users/create-1", Name: "Created", Age: 20}
+		created, err := userCollection.Create("users/create-1", &user)
+		require.NoError(t, err, "Failed to create document")
+		assert.NotEmpty(t, created.ChangeVector)
+
+		_, err = userCollection.Create("users/create-1", &user)
+		assert.ErrorIs(t, err, interfaces.ErrDocumentAlreadyExists, "Creating an existing ID should fail")
+
+		_, err = userCollection.Replace("users/replace-missing", &user)
+		assert.ErrorIs(t, err, interfaces.ErrNotFound, "Replacing a missing document should fail")
+
+		user.Name = "Replaced"
+		replacedResult, err := userCollection.Replace("users/create-1", &user)
+		require.NoError(t, err, "Failed to replace document")
+		assert.NotEqual(t, created.ChangeVector, replacedResult.ChangeVector)
+		replaced, err := userCollection.LoadByID("users/create-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Replaced", replaced.Name)
+
+		_, err = userCollection.Upsert("users/upsert-1", &TestUser{ID: "users/upsert-1", Name: "Upserted"})
+		require.NoError(t, err)
+		_, err = userCollection.Upsert("users/upsert-1", &TestUser{ID: "users/upsert-1", Name: "Upserted Again"})
+		require.NoError(t, err)
+		upserted, err := userCollection.LoadByID("users/upsert-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Upserted Again", upserted.Name)
+
+		// Without an ID, Create uses the collection's ID strategy like Store
+		generated := TestUser{Name: "Created Without ID"}
+		created, err = userCollection.Create("", &generated)
+		require.NoError(t, err, "Failed to create document without an ID")
+		defer userCollection.Delete(created.ID)
+		assert.Regexp(t, `^users/\d+-[A-Z]+$`, created.ID, "HiLo is the default")
+		assert.Equal(t, created.ID, generated.ID)
+
+		guids := NewCollectionWithOptions[TestUser](db, "Users", &interfaces.CollectionOptions{IDStrategy: interfaces.IDStrategyGUID})
+		created, err = guids.Create("", &TestUser{Name: "Created With GUID"})
+		require.NoError(t, err)
+		defer guids.Delete(created.ID)
+		assert.Regexp(t, `^users/[0-9a-f-]{36}$`, created.ID)
+
+		// Raw writes are serialized like session stores, so sessions load them alike
+		loaded, err := guids.LoadWithMetadata(created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, created.ID, loaded.Document.ID)
+		assert.Equal(t, "Created With GUID", loaded.Document.Name)
+	})
+
+	t.Run("StoreReturnsAssignedID", func(t *testing.T) {
+		user := TestUser{Name: "Generated", Age: 50}
+		result, err := userCollection.Store("", &user)
+		require.NoError(t, err, "Failed to store document without an ID")
+		defer userCollection.Delete(result.ID)
+
+		assert.NotEmpty(t, result.ID, "The generated ID is returned")
+		assert.NotEmpty(t, result.ChangeVector)
+		assert.Equal(t, result.ID, user.ID, "The generated ID is written back into the document")
+
+		exists, err := userCollection.ExistsWithChangeVector(result.ID)
+		require.NoError(t, err)
+		assert.Equal(t, result.ChangeVector, exists)
+
+		results, err := userCollection.StoreMultiple(map[string]*TestUser{
+			"users/multi-1": {ID: "users/multi-1", Name: "Multi"},
+			"":              {Name: "Multi Generated"},
+		})
+		require.NoError(t, err)
+		defer userCollection.DeleteMultiple([]string{"users/multi-1", results[""].ID})
+
+		assert.Equal(t, "users/multi-1", results["users/multi-1"].ID)
+		assert.NotEmpty(t, results[""].ID, "The document under the empty key reports its generated ID")
 	})
 
 	t.Run("DeleteTypedDocuments", func(t *testing.T) {
@@ -234,6 +595,40 @@ func TestCollectionService(t *testing.T) {
 			assert.False(t, exists, "Document %s should be deleted", id)
 		}
 	})
+
+	t.Run("DeleteMultipleWithOptions", func(t *testing.T) {
+		stored, err := userCollection.StoreMultiple(map[string]*TestUser{
+			"users/delete-1": {Name: "Delete One", Email: "delete1@example.com"},
+			"users/delete-2": {Name: "Delete Two", Email: "delete2@example.com"},
+			"users/delete-3": {Name: "Delete Three", Email: "delete3@example.com"},
+		})
+		require.NoError(t, err)
+
+		// Missing IDs are skipped and not reported as deleted
+		deleted, err := userCollection.DeleteMultipleWithOptions([]string{"users/delete-1", "users/missing"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"users/delete-1"}, deleted)
+
+		// A stale change vector fails the whole batch
+		stale := stored["users/delete-2"].ChangeVector
+		current, err := userCollection.Store("users/delete-2", &TestUser{Name: "Delete Two", Email: "changed@example.com"})
+		require.NoError(t, err)
+
+		ids := []string{"users/delete-2", "users/delete-3"}
+		_, err = userCollection.DeleteMultipleWithOptions(ids, &interfaces.DeleteOptions{
+			ChangeVectors: map[string]string{"users/delete-2": stale},
+		})
+		assert.ErrorIs(t, err, interfaces.ErrConcurrencyConflict)
+		exists, err := userCollection.Exists("users/delete-3")
+		require.NoError(t, err)
+		assert.True(t, exists, "A failed batch deletes nothing")
+
+		deleted, err = userCollection.DeleteMultipleWithOptions(ids, &interfaces.DeleteOptions{
+			ChangeVectors: map[string]string{"users/delete-2": current.ChangeVector},
+		})
+		require.NoError(t, err, "Delete with the current change vector should succeed")
+		assert.Equal(t, ids, deleted)
+	})
 }
 
 func TestGenericQueryOperations(t *testing.T) {
@@ -246,7 +641,7 @@ func TestGenericQueryOperations(t *testing.T) {
 	// Set up test data
 	productCollection := NewCollection[TestProduct](db, "Products")
 
-	products := map[string]TestProduct{
+	products := map[string]*TestProduct{
 		"products/laptop": {
 			ID:          "products/laptop",
 			Name:        "Gaming Laptop",
@@ -273,7 +668,7 @@ func TestGenericQueryOperations(t *testing.T) {
 		},
 	}
 
-	err = productCollection.StoreMultiple(products)
+	_, err = productCollection.StoreMultiple(products)
 	require.NoError(t, err, "Failed to store test products")
 
 	t.Run("GenericQueryAll", func(t *testing.T) {
@@ -325,9 +720,101 @@ func TestGenericQueryOperations(t *testing.T) {
 		assert.True(t, found, "Should find Gaming Laptop in search results")
 	})
 
+	t.Run("RawQueryProjection", func(t *testing.T) {
+		result, err := RawQuery(db, "from Products where price > $min select name, price", map[string]interface{}{"min": 100})
+		require.NoError(t, err, "Failed to run raw query")
+
+		names := make(map[string]bool)
+		for _, row := range result.Results {
+			name, _ := row["name"].(string)
+			names[name] = true
+			assert.NotContains(t, row, "description", "Projection should only return selected fields")
+		}
+		assert.True(t, names["Gaming Laptop"])
+		assert.True(t, names["Mechanical Keyboard"])
+		assert.False(t, names["Wireless Mouse"])
+	})
+
+	t.Run("IndexAdministration", func(t *testing.T) {
+		// The raw query above created an auto index over Products
+		indexes, err := db.GetIndexes()
+		require.NoError(t, err, "Failed to list indexes")
+
+		var autoIndex string
+		for _, index := range indexes {
+			if strings.HasPrefix(index.Name, "Auto/Products") {
+				autoIndex = index.Name
+				assert.Contains(t, index.Collections, "Products")
+			}
+		}
+		require.NotEmpty(t, autoIndex, "Should list the auto index over Products")
+
+		assert.NoError(t, db.ResetIndex(autoIndex))
+		assert.Error(t, db.ResetIndex("Missing/Index"))
+	})
+
 	// Clean up test products
 	t.Cleanup(func() {
 		productIDs := []string{"products/laptop", "products/mouse", "products/keyboard"}
 		productCollection.DeleteMultiple(productIDs)
 	})
 }
+
+func TestDocumentExpiration(t *testing.T) {
+	testConfig, err := LoadTestConfig(testConfigPath)
+	require.NoError(t, err, "Failed to load test configuration")
+
+	db, cleanup := SetupTestDatabase(t, testConfig)
+	defer cleanup()
+
+	err = db.ConfigureExpiration(&interfaces.ExpirationOptions{DeleteFrequency: time.Minute})
+	require.NoError(t, err, "Failed to enable expiration")
+
+	tokenCollection := NewCollectionWithOptions[TestUser](db, "Users", &interfaces.CollectionOptions{
+		DefaultTTL: time.Hour,
+	})
+
+	// readExpires returns the @expires metadata stored for a document
+	readExpires := func(t *testing.T, id string) string {
+		session, err := db.GetStore().(*ravendbclient.DocumentStore).OpenSession(db.GetDatabase())
+		require.NoError(t, err)
+		defer session.Close()
+
+		var user *TestUser
+		require.NoError(t, session.Load(&user, id))
+		require.NotNil(t, user)
+
+		metadata, err := session.Advanced().GetMetadataFor(user)
+		require.NoError(t, err)
+		expires, _ := metadata.Get("@expires")
+		value, _ := expires.(string)
+		return value
+	}
+
+	t.Run("StoreWithExplicitExpiration", func(t *testing.T) {
+		expiresAt := time.Now().Add(2 * time.Hour).UTC()
+		user := TestUser{ID: "users/expiring-1", Name: "Short Lived", Created: time.Now()}
+
+		_, err := tokenCollection.StoreWithOptions("users/expiring-1", &user, &interfaces.StoreOptions{Expires: &expiresAt})
+		require.NoError(t, err, "Failed to store document with expiration")
+
+		parsed, err := ravendbclient.ParseTime(readExpires(t, "users/expiring-1"))
+		require.NoError(t, err)
+		assert.WithinDuration(t, expiresAt, parsed, time.Second)
+	})
+
+	t.Run("StoreWithDefaultTTL", func(t *testing.T) {
+		user := TestUser{ID: "users/expiring-2", Name: "Default TTL", Created: time.Now()}
+
+		_, err := tokenCollection.Store("users/expiring-2", &user)
+		require.NoError(t, err, "Failed to store document with default TTL")
+
+		parsed, err := ravendbclient.ParseTime(readExpires(t, "users/expiring-2"))
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), parsed, time.Minute)
+	})
+
+	t.Cleanup(func() {
+		tokenCollection.DeleteMultiple([]string{"users/expiring-1", "users/expiring-2"})
+	})
+}
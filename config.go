@@ -1,9 +1,13 @@
 package ravendb
 
+import "github.com/ternarybob/ravendb/interfaces"
+
 // Config holds configuration for RavenDB connection
 type Config struct {
-	URLs     []string `json:"urls"`
-	Database string   `json:"database"`
+	URLs           []string                          `json:"urls"`
+	Database       string                            `json:"database"`
+	Retry          *interfaces.RetryPolicy           `json:"retry,omitempty"`          // Nil disables retries
+	CircuitBreaker *interfaces.CircuitBreakerOptions `json:"circuitBreaker,omitempty"` // Nil disables the circuit breaker
 }
 
 // NewConfig creates a new configuration with default values
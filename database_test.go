@@ -4,8 +4,10 @@ import (
 	"testing"
 	"time"
 
+	ravendbclient "github.com/ravendb/ravendb-go-client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/ternarybob/ravendb/interfaces"
 )
 
 const testConfigPath = "config/test_config.toml"
@@ -71,6 +73,40 @@ func TestDatabaseAvailability(t *testing.T) {
 	assert.NotNil(t, store)
 }
 
+func TestDatabaseStatus(t *testing.T) {
+	testConfig, err := LoadTestConfig(testConfigPath)
+	require.NoError(t, err, "Failed to load test configuration")
+
+	db, cleanup := SetupTestDatabase(t, testConfig)
+	defer cleanup()
+
+	_, err = db.Store("users/status-1", &TestUser{ID: "users/status-1", Name: "Status User"})
+	require.NoError(t, err, "Failed to store document")
+	defer db.Delete("users/status-1")
+
+	t.Run("Typed Statistics", func(t *testing.T) {
+		status, err := db.GetStatus()
+		require.NoError(t, err, "Failed to get database status")
+
+		assert.Equal(t, interfaces.StatusConnected, status.Status)
+		assert.GreaterOrEqual(t, status.DocumentCount, int64(1))
+		assert.GreaterOrEqual(t, status.Collections["TestUsers"], int64(1))
+		assert.Positive(t, status.SizeOnDisk)
+		assert.Positive(t, status.LastDocEtag)
+		assert.NotNil(t, status.StaleIndexes)
+	})
+
+	t.Run("Map Form", func(t *testing.T) {
+		status, err := db.GetDatabaseStatus()
+		require.NoError(t, err, "Failed to get database status")
+
+		assert.Equal(t, "connected", status["status"])
+		count, ok := status["document_count"].(int64)
+		require.True(t, ok, "Expected int64 document count")
+		assert.GreaterOrEqual(t, count, int64(1))
+	})
+}
+
 func TestBasicCRUDOperations(t *testing.T) {
 	testConfig, err := LoadTestConfig(testConfigPath)
 	require.NoError(t, err, "Failed to load test configuration")
@@ -90,7 +126,7 @@ func TestBasicCRUDOperations(t *testing.T) {
 		}
 
 		// Store document
-		err := db.Store("users/test-1", &user)
+		_, err := db.Store("users/test-1", &user)
 		assert.NoError(t, err, "Failed to store document")
 
 		// Load document
@@ -119,6 +155,30 @@ func TestBasicCRUDOperations(t *testing.T) {
 		assert.False(t, exists, "Document should not exist")
 	})
 
+	t.Run("ExistsWithChangeVector", func(t *testing.T) {
+		// Change vectors come from headers and metadata, without document bodies
+		session, err := db.GetStore().(*ravendbclient.DocumentStore).OpenSession(db.GetDatabase())
+		require.NoError(t, err)
+		defer session.Close()
+
+		var loaded *TestUser
+		require.NoError(t, session.Load(&loaded, "users/test-1"))
+		expected, err := session.Advanced().GetChangeVectorFor(loaded)
+		require.NoError(t, err)
+
+		changeVector, err := db.ExistsWithChangeVector("users/test-1")
+		assert.NoError(t, err)
+		assert.Equal(t, *expected, changeVector, "HEAD should report the stored change vector")
+
+		changeVector, err = db.ExistsWithChangeVector("users/non-existent")
+		assert.NoError(t, err, "A missing document is not an error")
+		assert.Empty(t, changeVector)
+
+		changeVectors, err := db.ExistsMany([]string{"users/test-1", "users/non-existent"})
+		assert.NoError(t, err, "Failed to check existence of several documents")
+		assert.Equal(t, map[string]string{"users/test-1": *expected}, changeVectors)
+	})
+
 	t.Run("UpdateDocument", func(t *testing.T) {
 		// Update document
 		updates := map[string]interface{}{
@@ -136,6 +196,41 @@ func TestBasicCRUDOperations(t *testing.T) {
 		assert.False(t, updatedUser.IsActive)
 	})
 
+	t.Run("UpdateNestedFieldsInPlace", func(t *testing.T) {
+		document := map[string]interface{}{
+			"name":    "Nested",
+			"address": map[string]interface{}{"street": "1 Main St", "city": "Springfield"},
+			"@metadata": map[string]interface{}{
+				"@collection": "Users",
+				"tenant":      "acme",
+			},
+		}
+		_, err := db.Store("users/nested", document)
+		require.NoError(t, err)
+
+		err = db.Update("users/nested", map[string]interface{}{
+			"address.city":    "Shelbyville",
+			"contact.email":   "nested@example.com",
+			"preferences.tag": "vip",
+		})
+		require.NoError(t, err, "Failed to update nested fields")
+
+		var updated *map[string]interface{}
+		require.NoError(t, db.LoadByID("users/nested", &updated))
+		address := (*updated)["address"].(map[string]interface{})
+		assert.Equal(t, "Shelbyville", address["city"])
+		assert.Equal(t, "1 Main St", address["street"], "Sibling fields are kept")
+		assert.Equal(t, "nested@example.com", (*updated)["contact"].(map[string]interface{})["email"], "Missing objects are created")
+		assert.Equal(t, "Nested", (*updated)["name"])
+
+		metadata := (*updated)["@metadata"].(map[string]interface{})
+		assert.Equal(t, "Users", metadata["@collection"], "The collection is kept")
+		assert.Equal(t, "acme", metadata["tenant"], "Custom metadata is kept")
+
+		err = db.Update("users/nested", map[string]interface{}{"@metadata.@collection": "Other"})
+		assert.Error(t, err, "Metadata cannot be updated")
+	})
+
 	t.Run("StoreMultipleDocuments", func(t *testing.T) {
 		users := map[string]interface{}{
 			"users/test-2": &TestUser{
@@ -156,7 +251,7 @@ func TestBasicCRUDOperations(t *testing.T) {
 			},
 		}
 
-		err := db.StoreMultiple(users)
+		_, err := db.StoreMultiple(users)
 		assert.NoError(t, err, "Failed to store multiple documents")
 
 		// Verify documents were stored
@@ -169,6 +264,18 @@ func TestBasicCRUDOperations(t *testing.T) {
 		assert.True(t, exists)
 	})
 
+	t.Run("LoadMultipleDocuments", func(t *testing.T) {
+		var users []TestUser
+		missing, err := db.LoadMultipleByIDsWithMissing([]string{"users/test-3", "users/missing", "users/test-2"}, &users)
+		require.NoError(t, err, "Failed to load multiple documents")
+
+		// Typed in the requested order, with the missing ID reported
+		require.Len(t, users, 2)
+		assert.Equal(t, "Bob Johnson", users[0].Name)
+		assert.Equal(t, "Jane Smith", users[1].Name)
+		assert.Equal(t, []string{"users/missing"}, missing)
+	})
+
 	t.Run("DeleteDocument", func(t *testing.T) {
 		// Delete document
 		err := db.Delete("users/test-1")
@@ -193,3 +300,177 @@ func TestBasicCRUDOperations(t *testing.T) {
 		}
 	})
 }
+
+func TestTypedErrors(t *testing.T) {
+	testConfig, err := LoadTestConfig(testConfigPath)
+	require.NoError(t, err, "Failed to load test configuration")
+
+	db, cleanup := SetupTestDatabase(t, testConfig)
+	defer cleanup()
+
+	userCollection := NewCollection[TestUser](db, "Users")
+
+	t.Run("LoadMissingDocument", func(t *testing.T) {
+		user, err := userCollection.LoadByID("users/missing")
+		assert.Nil(t, user)
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+
+		var opErr *interfaces.OperationError
+		require.ErrorAs(t, err, &opErr)
+		assert.Equal(t, "users/missing", opErr.ID)
+		assert.Equal(t, "Users", opErr.Collection)
+
+		var loaded *TestUser
+		err = db.LoadByID("users/missing", &loaded)
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+	})
+
+	t.Run("DeleteMissingDocument", func(t *testing.T) {
+		err := userCollection.Delete("users/missing")
+		assert.True(t, interfaces.IsNotFound(err), "Deleting a missing document should report not found")
+
+		err = db.Delete("users/missing")
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+	})
+
+	t.Run("DeleteWithStaleChangeVector", func(t *testing.T) {
+		// Age does not decode into TestUser, which deletes by ID must not care about
+		document := map[string]interface{}{
+			"name":      "Stale",
+			"age":       "unknown",
+			"@metadata": map[string]interface{}{"@collection": "Users"},
+		}
+		_, err := db.Store("users/stale", document)
+		require.NoError(t, err)
+
+		readChangeVector := func() string {
+			session, err := db.GetStore().(*ravendbclient.DocumentStore).OpenSession(db.GetDatabase())
+			require.NoError(t, err)
+			defer session.Close()
+
+			var loaded *map[string]interface{}
+			require.NoError(t, session.Load(&loaded, "users/stale"))
+			changeVector, err := session.Advanced().GetChangeVectorFor(loaded)
+			require.NoError(t, err)
+			return *changeVector
+		}
+
+		stale := readChangeVector()
+		document["name"] = "Changed"
+		_, err = db.Store("users/stale", document)
+		require.NoError(t, err)
+
+		err = userCollection.DeleteWithOptions("users/stale", &interfaces.DeleteOptions{ChangeVector: stale})
+		assert.ErrorIs(t, err, interfaces.ErrConcurrencyConflict)
+
+		err = userCollection.DeleteWithOptions("users/stale", &interfaces.DeleteOptions{ChangeVector: readChangeVector()})
+		assert.NoError(t, err, "Delete with the current change vector should succeed")
+	})
+
+	t.Run("UpdateMissingDocument", func(t *testing.T) {
+		err := db.Update("users/missing", map[string]interface{}{"age": 1})
+		assert.ErrorIs(t, err, interfaces.ErrNotFound)
+	})
+
+	t.Run("InvalidQueryCarriesRQL", func(t *testing.T) {
+		_, err := userCollection.Query(&interfaces.QueryOptions{WhereClause: "age >>> 1"})
+		require.Error(t, err)
+
+		var opErr *interfaces.OperationError
+		require.ErrorAs(t, err, &opErr)
+		assert.Contains(t, opErr.Query, "age >>> 1")
+	})
+}
+
+func TestRetryPolicy(t *testing.T) {
+	// An unreachable node fails every attempt with a transient error
+	attempts := 0
+	policy := interfaces.DefaultRetryPolicy()
+	policy.InitialBackoff = 10 * time.Millisecond
+	policy.Retryable = func(err error) bool {
+		attempts++
+		return true
+	}
+
+	config := NewSingleNodeConfig("http://127.0.0.1:1", "RetryTest")
+	config.Retry = policy
+
+	db, err := NewDatabase(config)
+	require.NoError(t, err, "Failed to create database service")
+	defer db.Close()
+
+	t.Run("Retries Idempotent Operations", func(t *testing.T) {
+		attempts = 0
+		// The client only loads into a pointer to a struct pointer; anything else fails argument
+		// validation before a request is made, so the retries would not exercise the network
+		var user *TestUser
+		err := db.LoadByID("users/1", &user)
+		assert.ErrorIs(t, err, interfaces.ErrUnavailable, "The load should reach the unreachable node")
+		assert.Equal(t, policy.MaxAttempts, attempts, "Expected one classification per attempt")
+	})
+
+	t.Run("Skips Non-Idempotent Writes", func(t *testing.T) {
+		attempts = 0
+		_, err := db.Store("", &TestUser{Name: "No ID"})
+		assert.Error(t, err)
+		assert.Equal(t, 0, attempts, "Stores without an ID must not be retried")
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	// An unreachable node fails every operation with ErrUnavailable
+	var transitions []interfaces.CircuitState
+	breaker := interfaces.DefaultCircuitBreakerOptions()
+	breaker.FailureThreshold = 2
+	breaker.OpenTimeout = 100 * time.Millisecond
+	breaker.OnStateChange = func(from, to interfaces.CircuitState) {
+		transitions = append(transitions, to)
+	}
+
+	config := NewSingleNodeConfig("http://127.0.0.1:1", "CircuitBreakerTest")
+	config.CircuitBreaker = breaker
+
+	db, err := NewDatabase(config)
+	require.NoError(t, err, "Failed to create database service")
+	defer db.Close()
+
+	var user *TestUser
+
+	t.Run("Trips After Threshold", func(t *testing.T) {
+		for i := 0; i < breaker.FailureThreshold; i++ {
+			err := db.LoadByID("users/1", &user)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, interfaces.ErrUnavailable)
+		}
+
+		err := db.LoadByID("users/1", &user)
+		assert.ErrorIs(t, err, interfaces.ErrCircuitOpen, "Open circuit should fail fast")
+
+		var openErr *interfaces.CircuitOpenError
+		assert.ErrorAs(t, err, &openErr)
+		assert.Equal(t, []interfaces.CircuitState{interfaces.CircuitOpen}, transitions)
+	})
+
+	t.Run("Status Reports State", func(t *testing.T) {
+		status, err := db.GetDatabaseStatus()
+		assert.ErrorIs(t, err, interfaces.ErrCircuitOpen)
+		assert.Equal(t, "unavailable", status["status"])
+
+		circuit, ok := status["circuit_breaker"].(map[string]interface{})
+		require.True(t, ok, "Expected circuit breaker status")
+		assert.Equal(t, interfaces.CircuitOpen, circuit["state"])
+	})
+
+	t.Run("Half-Open Probe Reopens", func(t *testing.T) {
+		time.Sleep(breaker.OpenTimeout)
+
+		err := db.LoadByID("users/1", &user)
+		assert.ErrorIs(t, err, interfaces.ErrUnavailable, "Probe should reach the server")
+		assert.Equal(t, []interfaces.CircuitState{
+			interfaces.CircuitOpen, interfaces.CircuitHalfOpen, interfaces.CircuitOpen,
+		}, transitions)
+
+		err = db.LoadByID("users/1", &user)
+		assert.ErrorIs(t, err, interfaces.ErrCircuitOpen)
+	})
+}
@@ -64,7 +64,7 @@ func basicCRUDExample(db interfaces.IRavenDBService) {
 		IsActive: true,
 	}
 
-	if err := db.Store("users/1", user); err != nil {
+	if _, err := db.Store("users/1", &user); err != nil {
 		log.Printf("Failed to store user: %v", err)
 		return
 	}
@@ -103,7 +103,7 @@ func collectionServiceExample(db interfaces.IRavenDBService) {
 	userCollection := ravendb.NewCollection[User](db, "Users")
 
 	// Store multiple users
-	users := map[string]User{
+	users := map[string]*User{
 		"users/2": {
 			ID:       "users/2",
 			Name:     "Jane Smith",
@@ -120,7 +120,7 @@ func collectionServiceExample(db interfaces.IRavenDBService) {
 		},
 	}
 
-	if err := userCollection.StoreMultiple(users); err != nil {
+	if _, err := userCollection.StoreMultiple(users); err != nil {
 		log.Printf("Failed to store multiple users: %v", err)
 		return
 	}
@@ -155,7 +155,7 @@ func genericQueryExample(db interfaces.IRavenDBService) {
 	// Create product documents for demonstration
 	productCollection := ravendb.NewCollection[Product](db, "Products")
 
-	products := map[string]Product{
+	products := map[string]*Product{
 		"products/1": {
 			ID:          "products/1",
 			Name:        "Laptop",
@@ -174,7 +174,7 @@ func genericQueryExample(db interfaces.IRavenDBService) {
 		},
 	}
 
-	if err := productCollection.StoreMultiple(products); err != nil {
+	if _, err := productCollection.StoreMultiple(products); err != nil {
 		log.Printf("Failed to store products: %v", err)
 		return
 	}
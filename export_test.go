@@ -0,0 +1,126 @@
+package ravendb
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	ravendbclient "github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+func TestExportImport(t *testing.T) {
+	testConfig, err := LoadTestConfig(testConfigPath)
+	require.NoError(t, err, "Failed to load test configuration")
+
+	// Export and import between two throwaway databases so counts are exact
+	newThrowawayDatabase := func(prefix string) interfaces.IRavenDBService {
+		config := &Config{
+			URLs:     testConfig.Database.URLs,
+			Database: fmt.Sprintf("%s_%d", prefix, time.Now().UnixNano()),
+		}
+		db, err := NewDatabase(config)
+		require.NoError(t, err, "Failed to create database service")
+		t.Cleanup(func() {
+			NewAdmin(db).DeleteDatabase(config.Database, &interfaces.DatabaseDeleteOptions{HardDelete: true})
+			db.Close()
+		})
+		require.NoError(t, db.Init(), "Failed to initialize database")
+		return db
+	}
+	source := newThrowawayDatabase("ExportSource")
+	target := newThrowawayDatabase("ExportTarget")
+
+	users := NewCollection[TestUser](source, "Users")
+	for i, age := range []int{25, 35, 45} {
+		id := fmt.Sprintf("users/export-%d", i+1)
+		_, err := users.Store(id, &TestUser{ID: id, Name: fmt.Sprintf("Export User %d", i+1), Age: age})
+		require.NoError(t, err)
+	}
+
+	// Attach a file to one exported user through the client directly
+	session, err := source.GetStore().(*ravendbclient.DocumentStore).OpenSession(source.GetDatabase())
+	require.NoError(t, err)
+	require.NoError(t, session.Advanced().Attachments().StoreByID("users/export-2", "avatar.txt", strings.NewReader("avatar"), "text/plain"))
+	require.NoError(t, session.SaveChanges())
+	session.Close()
+
+	var snapshot bytes.Buffer
+
+	t.Run("Export", func(t *testing.T) {
+		var reports []interfaces.TransferProgress
+		result, err := Export(source, &snapshot, &interfaces.ExportOptions{
+			Collections:        []string{"Users"},
+			Where:              "age >= $minAge",
+			Parameters:         map[string]interface{}{"minAge": 30},
+			IncludeAttachments: true,
+			ProgressInterval:   1,
+			Progress: func(p interfaces.TransferProgress) {
+				reports = append(reports, p)
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), result.Documents)
+		assert.Equal(t, int64(1), result.Attachments)
+		assert.Equal(t, int64(2), result.Collections["Users"])
+
+		require.NotEmpty(t, reports)
+		assert.True(t, reports[len(reports)-1].Completed, "Final report should be marked completed")
+
+		scanner := bufio.NewScanner(bytes.NewReader(snapshot.Bytes()))
+		lines := 0
+		for scanner.Scan() {
+			var document map[string]interface{}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &document))
+			metadata := document["@metadata"].(map[string]interface{})
+			assert.Equal(t, "Users", metadata["@collection"])
+			assert.NotContains(t, metadata, "@change-vector", "Server-managed metadata should be dropped")
+			lines++
+		}
+		assert.Equal(t, 2, lines)
+	})
+
+	t.Run("Import with Remapped IDs", func(t *testing.T) {
+		result, err := Import(target, bytes.NewReader(snapshot.Bytes()), &interfaces.ImportOptions{
+			RemapID: func(id, collection string) string {
+				return "copy-" + id
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), result.Documents)
+		assert.Equal(t, int64(1), result.Attachments)
+
+		count, err := target.CountDocuments("Users")
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		exists, err := target.Exists("copy-users/export-2")
+		require.NoError(t, err)
+		assert.True(t, exists, "Documents should be stored under remapped IDs")
+
+		status, err := target.GetStatus()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), status.AttachmentCount)
+	})
+
+	t.Run("Import Filter", func(t *testing.T) {
+		result, err := Import(target, bytes.NewReader(snapshot.Bytes()), &interfaces.ImportOptions{
+			Filter: func(id, collection string, document map[string]interface{}) bool {
+				return id == "users/export-3"
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), result.Documents)
+		assert.Equal(t, int64(1), result.Skipped)
+
+		exists, err := target.Exists("users/export-3")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
@@ -0,0 +1,34 @@
+package interfaces
+
+import "time"
+
+// DatabaseCreateOptions describes a database to create
+type DatabaseCreateOptions struct {
+	Name              string            `json:"name"`
+	ReplicationFactor int               `json:"replicationFactor,omitempty"` // Defaults to 1, or the number of Members when set
+	Members           []string          `json:"members,omitempty"`           // Cluster node tags to place the database on, e.g. "A", "B"
+	Encrypted         bool              `json:"encrypted,omitempty"`         // Requires a secured server and a registered encryption key
+	Disabled          bool              `json:"disabled,omitempty"`          // Create the database in the disabled state
+	DataDirectory     string            `json:"dataDirectory,omitempty"`
+	Settings          map[string]string `json:"settings,omitempty"` // Database configuration keys, e.g. "Indexing.MapTimeoutInSec"
+}
+
+// DatabaseDeleteOptions controls how a database is deleted
+type DatabaseDeleteOptions struct {
+	HardDelete                bool          `json:"hardDelete,omitempty"` // Also remove the data files from disk
+	FromNodes                 []string      `json:"fromNodes,omitempty"`  // Only remove the database from these node tags
+	TimeToWaitForConfirmation time.Duration `json:"timeToWaitForConfirmation,omitempty"`
+}
+
+// IRavenAdminService defines server-level database administration
+type IRavenAdminService interface {
+	ListDatabases() ([]string, error)
+	DatabaseExists(name string) (bool, error)
+	CreateDatabase(options *DatabaseCreateOptions) error
+	DeleteDatabase(name string, options *DatabaseDeleteOptions) error
+	EnableDatabase(name string) error
+	DisableDatabase(name string) error
+
+	// EnsureDatabase creates the database unless it already exists, reporting whether it was created
+	EnsureDatabase(options *DatabaseCreateOptions) (bool, error)
+}
@@ -0,0 +1,104 @@
+package interfaces
+
+import "context"
+
+// SmugglerItem is a kind of database content transferred by a smuggler export or import
+type SmugglerItem string
+
+// Smuggler item kinds
+const (
+	SmugglerDocuments       SmugglerItem = "Documents"
+	SmugglerRevisions       SmugglerItem = "RevisionDocuments"
+	SmugglerIndexes         SmugglerItem = "Indexes"
+	SmugglerIdentities      SmugglerItem = "Identities"
+	SmugglerCompareExchange SmugglerItem = "CompareExchange"
+	SmugglerCounters        SmugglerItem = "Counters"
+	SmugglerAttachments     SmugglerItem = "Attachments"
+	SmugglerConflicts       SmugglerItem = "Conflicts"
+	SmugglerSubscriptions   SmugglerItem = "Subscriptions"
+	SmugglerDatabaseRecord  SmugglerItem = "DatabaseRecord"
+)
+
+// DefaultSmugglerItems returns the items transferred when SmugglerOptions.Items is empty
+func DefaultSmugglerItems() []SmugglerItem {
+	return []SmugglerItem{
+		SmugglerDocuments, SmugglerRevisions, SmugglerIndexes, SmugglerIdentities,
+		SmugglerCompareExchange, SmugglerCounters, SmugglerAttachments, SmugglerSubscriptions,
+	}
+}
+
+// SmugglerOptions controls a smuggler export or import of a whole database
+type SmugglerOptions struct {
+	Items          []SmugglerItem `json:"items,omitempty"`          // Content to transfer, defaults to DefaultSmugglerItems
+	IncludeExpired bool           `json:"includeExpired,omitempty"` // Also transfer documents whose expiration has passed
+	Database       string         `json:"database,omitempty"`       // Import target, created if missing; defaults to the service's database
+}
+
+// BackupType selects a full logical backup or a binary snapshot
+type BackupType string
+
+// Backup types
+const (
+	BackupTypeBackup   BackupType = "Backup"
+	BackupTypeSnapshot BackupType = "Snapshot"
+)
+
+// BackupOptions configures a one-time backup to a local folder
+type BackupOptions struct {
+	FolderPath string     `json:"folderPath"`     // Folder on the server's file system
+	Type       BackupType `json:"type,omitempty"` // Defaults to BackupTypeBackup
+}
+
+// RestoreOptions configures restoring a local backup into a new database
+type RestoreOptions struct {
+	DatabaseName          string `json:"databaseName"`                    // Must not exist yet
+	BackupLocation        string `json:"backupLocation"`                  // Backup directory on the server, as reported in OperationState.BackupDirectory
+	LastFileNameToRestore string `json:"lastFileNameToRestore,omitempty"` // Restore up to this incremental backup file, defaults to all
+	DataDirectory         string `json:"dataDirectory,omitempty"`
+	DisableOngoingTasks   bool   `json:"disableOngoingTasks,omitempty"`
+	SkipIndexes           bool   `json:"skipIndexes,omitempty"`
+}
+
+// OperationStatus is the state of a long-running server operation
+type OperationStatus string
+
+// Operation states
+const (
+	OperationInProgress OperationStatus = "InProgress"
+	OperationCompleted  OperationStatus = "Completed"
+	OperationFaulted    OperationStatus = "Faulted"
+	OperationCancelled  OperationStatus = "Cancelled"
+)
+
+// OperationItemProgress counts the items of one kind processed by an operation
+type OperationItemProgress struct {
+	Read    int64 `json:"read"`
+	Skipped int64 `json:"skipped,omitempty"`
+	Errored int64 `json:"errored,omitempty"`
+}
+
+// OperationState describes the progress or outcome of a long-running server operation
+type OperationState struct {
+	ID              int64                            `json:"id"`
+	Status          OperationStatus                  `json:"status"`
+	Items           map[string]OperationItemProgress `json:"items,omitempty"` // Per item kind, e.g. "Documents" or "Indexes"
+	Messages        []string                         `json:"messages,omitempty"`
+	Error           string                           `json:"error,omitempty"`           // Set when the operation faulted
+	BackupDirectory string                           `json:"backupDirectory,omitempty"` // Set by completed backups
+}
+
+// Done reports whether the operation has finished, successfully or not
+func (s *OperationState) Done() bool {
+	return s.Status != OperationInProgress
+}
+
+// IRavenOperation tracks a long-running server operation such as a backup, restore or smuggler transfer
+type IRavenOperation interface {
+	// ID is the server's operation ID
+	ID() int64
+	// State fetches the operation's current progress
+	State() (*OperationState, error)
+	// Wait polls the operation until it finishes, passing each state to progress if it is not nil.
+	// It fails with ErrOperationFailed if the operation faulted or was cancelled.
+	Wait(ctx context.Context, progress func(*OperationState)) (*OperationState, error)
+}
@@ -0,0 +1,52 @@
+package interfaces
+
+import "time"
+
+// Document change types reported in DocumentChangeEvent.Type
+const (
+	DocumentChangePut      = "Put"
+	DocumentChangeDelete   = "Delete"
+	DocumentChangeConflict = "Conflict"
+)
+
+// DocumentChangeEvent describes a change to a single document
+type DocumentChangeEvent struct {
+	Type         string `json:"type"`
+	ID           string `json:"id"`
+	Collection   string `json:"collection"`
+	ChangeVector string `json:"changeVector,omitempty"`
+}
+
+// IndexChangeEvent describes a change to an index, such as a completed indexing batch
+type IndexChangeEvent struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// ChangesOptions configures change notification delivery and reconnection
+type ChangesOptions struct {
+	BufferSize int             `json:"bufferSize,omitempty"` // Event channel buffer per subscription, defaults to 100
+	RetryDelay time.Duration   `json:"retryDelay,omitempty"` // Wait between reconnect attempts, defaults to 1s
+	OnError    func(err error) `json:"-"`                    // Called when the connection fails, before reconnecting
+}
+
+// IRavenChangeSubscription delivers events of type E until it is closed
+type IRavenChangeSubscription[E any] interface {
+	// Events returns the channel events are delivered on; it is closed by Close.
+	// Delivery blocks while the buffer is full, so consumers must drain it promptly.
+	Events() <-chan E
+	Close() error
+}
+
+// IRavenChangesService defines the interface for document and index change notifications
+type IRavenChangesService interface {
+	ForDocument(id string) (IRavenChangeSubscription[DocumentChangeEvent], error)
+	ForDocumentsStartingWith(prefix string) (IRavenChangeSubscription[DocumentChangeEvent], error)
+	ForCollection(collection string) (IRavenChangeSubscription[DocumentChangeEvent], error)
+	ForAllDocuments() (IRavenChangeSubscription[DocumentChangeEvent], error)
+	ForIndex(indexName string) (IRavenChangeSubscription[IndexChangeEvent], error)
+	ForAllIndexes() (IRavenChangeSubscription[IndexChangeEvent], error)
+
+	// Close unsubscribes every subscription and releases the connection
+	Close() error
+}
@@ -0,0 +1,53 @@
+package interfaces
+
+import (
+	"fmt"
+	"time"
+)
+
+// CircuitState is the state of a database service's circuit breaker
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // Operations run normally
+	CircuitOpen     CircuitState = "open"      // Operations fail fast with ErrCircuitOpen
+	CircuitHalfOpen CircuitState = "half-open" // A limited number of probe operations run
+)
+
+// CircuitBreakerOptions controls when the circuit breaker trips and how it recovers
+type CircuitBreakerOptions struct {
+	FailureThreshold    int                         `json:"failureThreshold"`              // Consecutive failures that open the circuit
+	OpenTimeout         time.Duration               `json:"openTimeout,omitempty"`         // Time spent open before probing, defaults to 30s
+	HalfOpenMaxRequests int                         `json:"halfOpenMaxRequests,omitempty"` // Concurrent probes allowed while half-open, defaults to 1
+	SuccessThreshold    int                         `json:"successThreshold,omitempty"`    // Successful probes needed to close the circuit, defaults to 1
+	TripOn              []error                     `json:"-"`                             // Failure classes, defaults to ErrTimeout and ErrUnavailable
+	IsFailure           func(error) bool            `json:"-"`                             // Custom classifier, overrides TripOn when set
+	OnStateChange       func(from, to CircuitState) `json:"-"`                             // Called after every state transition
+}
+
+// DefaultCircuitBreakerOptions returns options that open after five consecutive failures for 30 seconds
+func DefaultCircuitBreakerOptions() *CircuitBreakerOptions {
+	return &CircuitBreakerOptions{
+		FailureThreshold:    5,
+		OpenTimeout:         30 * time.Second,
+		HalfOpenMaxRequests: 1,
+		SuccessThreshold:    1,
+		TripOn:              []error{ErrTimeout, ErrUnavailable},
+	}
+}
+
+// CircuitOpenError is returned without contacting the server while the circuit breaker is open
+type CircuitOpenError struct {
+	State   CircuitState `json:"state"`
+	RetryAt time.Time    `json:"retryAt"` // Earliest time a probe will be allowed
+}
+
+// Error describes the open circuit
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s (%s until %s)", ErrCircuitOpen.Error(), e.State, e.RetryAt.Format(time.RFC3339))
+}
+
+// Unwrap makes the error match ErrCircuitOpen
+func (e *CircuitOpenError) Unwrap() error {
+	return ErrCircuitOpen
+}
@@ -0,0 +1,130 @@
+package interfaces
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors classifying failures; test for them with errors.Is
+var (
+	ErrNotFound              = errors.New("document not found")
+	ErrConcurrencyConflict   = errors.New("concurrency conflict")
+	ErrDocumentAlreadyExists = errors.New("document already exists")
+	ErrDatabaseDoesNotExist  = errors.New("database does not exist")
+	ErrDatabaseAlreadyExists = errors.New("database already exists")
+	ErrUnauthorized          = errors.New("unauthorized")
+	ErrTimeout               = errors.New("operation timed out")
+	ErrUnavailable           = errors.New("server unavailable")
+	ErrCircuitOpen           = errors.New("circuit breaker is open")
+	ErrIndex                 = errors.New("index error")
+	ErrMigrationLocked       = errors.New("migrations are locked by another instance")
+	ErrOperationFailed       = errors.New("server operation failed")
+	ErrValidation            = errors.New("document failed validation")
+)
+
+// OperationError describes a failed database or collection operation. Kind holds the matching
+// sentinel error (if any) and Err the underlying client error; both are reachable through errors.Is.
+type OperationError struct {
+	Op         string `json:"op"`                   // Operation that failed, e.g. "failed to load document"
+	ID         string `json:"id,omitempty"`         // Document ID involved, if any
+	Collection string `json:"collection,omitempty"` // Collection involved, if any
+	Query      string `json:"query,omitempty"`      // RQL involved, if any
+	Kind       error  `json:"-"`
+	Err        error  `json:"-"`
+}
+
+// Error formats the operation, its context and the cause
+func (e *OperationError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Op)
+	if e.ID != "" {
+		b.WriteString(" " + e.ID)
+	}
+	if e.Collection != "" {
+		b.WriteString(" in collection " + e.Collection)
+	}
+	if e.Query != "" {
+		b.WriteString(fmt.Sprintf(" (query: %s)", e.Query))
+	}
+
+	switch {
+	case e.Err != nil:
+		b.WriteString(": " + e.Err.Error())
+	case e.Kind != nil:
+		b.WriteString(": " + e.Kind.Error())
+	}
+
+	return b.String()
+}
+
+// Unwrap exposes both the sentinel kind and the underlying error to errors.Is and errors.As
+func (e *OperationError) Unwrap() []error {
+	var errs []error
+	if e.Kind != nil {
+		errs = append(errs, e.Kind)
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// FieldError describes one invalid field of a document. Validators return it directly, several
+// joined with errors.Join, or a *ValidationError; any other error is reported without a field.
+type FieldError struct {
+	Field   string `json:"field,omitempty"` // JSON name or path of the field; empty for the document as a whole
+	Message string `json:"message"`
+}
+
+// Error formats the field and the problem with it
+func (e *FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError lists the invalid fields of a document rejected before it was sent to the server.
+// It matches ErrValidation through errors.Is.
+type ValidationError struct {
+	ID         string       `json:"id,omitempty"`
+	Collection string       `json:"collection,omitempty"`
+	Fields     []FieldError `json:"fields"`
+}
+
+// Error formats the document and each invalid field
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString("invalid document")
+	if e.ID != "" {
+		b.WriteString(" " + e.ID)
+	}
+	if e.Collection != "" {
+		b.WriteString(" in collection " + e.Collection)
+	}
+	for i, field := range e.Fields {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+		b.WriteString(field.Error())
+	}
+	return b.String()
+}
+
+// Unwrap exposes ErrValidation to errors.Is
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// IsNotFound reports whether err indicates a missing document
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConcurrencyConflict reports whether err indicates a change vector mismatch
+func IsConcurrencyConflict(err error) bool {
+	return errors.Is(err, ErrConcurrencyConflict)
+}
@@ -0,0 +1,43 @@
+package interfaces
+
+import "time"
+
+// ExportOptions controls which documents Export writes
+type ExportOptions struct {
+	Collections        []string               `json:"collections,omitempty"`        // Collections to export, defaults to every user collection
+	Where              string                 `json:"where,omitempty"`              // RQL where clause applied to each collection, e.g. "Age >= $min"
+	Parameters         map[string]interface{} `json:"parameters,omitempty"`         // Values for $-parameters in Where
+	IncludeAttachments bool                   `json:"includeAttachments,omitempty"` // Embed attachment contents (base64) in @metadata.@attachments
+	IncludeCounters    bool                   `json:"includeCounters,omitempty"`    // Embed counter values in @metadata.@counters
+	ProgressInterval   int                    `json:"progressInterval,omitempty"`   // Documents between progress reports, defaults to 1000
+	Progress           func(TransferProgress) `json:"-"`
+}
+
+// ImportOptions controls how Import writes documents
+type ImportOptions struct {
+	Collections      []string                                                          `json:"collections,omitempty"`      // Collections to import, defaults to all in the input
+	Filter           func(id, collection string, document map[string]interface{}) bool `json:"-"`                          // Return false to skip a document
+	RemapID          func(id, collection string) string                                `json:"-"`                          // Returns the ID to store a document under; empty keeps the original
+	BatchSize        int                                                               `json:"batchSize,omitempty"`        // Documents per bulk insert, defaults to 1000
+	ProgressInterval int                                                               `json:"progressInterval,omitempty"` // Documents between progress reports, defaults to 1000
+	Progress         func(TransferProgress)                                            `json:"-"`
+}
+
+// TransferProgress reports the running totals of an export or import
+type TransferProgress struct {
+	Collection  string `json:"collection"` // Collection of the most recent document
+	Documents   int64  `json:"documents"`
+	Attachments int64  `json:"attachments"`
+	Counters    int64  `json:"counters"`
+	Completed   bool   `json:"completed"` // Set on the final report
+}
+
+// TransferResult summarises a completed export or import
+type TransferResult struct {
+	Documents   int64            `json:"documents"`
+	Attachments int64            `json:"attachments"`
+	Counters    int64            `json:"counters"`
+	Skipped     int64            `json:"skipped,omitempty"`     // Documents excluded by an import filter
+	Collections map[string]int64 `json:"collections,omitempty"` // Documents per collection
+	Duration    time.Duration    `json:"duration"`
+}
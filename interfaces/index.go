@@ -0,0 +1,18 @@
+package interfaces
+
+import "time"
+
+// IndexInfo describes an index and its current statistics
+type IndexInfo struct {
+	Name             string     `json:"name"`
+	Type             string     `json:"type"`     // e.g. "Map", "MapReduce", "AutoMap"
+	State            string     `json:"state"`    // e.g. "Normal", "Disabled", "Error"
+	Priority         string     `json:"priority"` // "Low", "Normal" or "High"
+	LockMode         string     `json:"lockMode"`
+	IsStale          bool       `json:"isStale"`
+	EntriesCount     int        `json:"entriesCount"`
+	ErrorsCount      int        `json:"errorsCount"`
+	Collections      []string   `json:"collections,omitempty"` // Collections the index maps
+	LastIndexingTime *time.Time `json:"lastIndexingTime,omitempty"`
+	LastQueryingTime *time.Time `json:"lastQueryingTime,omitempty"`
+}
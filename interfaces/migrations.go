@@ -0,0 +1,67 @@
+package interfaces
+
+import "time"
+
+// Migration is a versioned, one-off transformation of the database
+type Migration struct {
+	Version int64                             `json:"version"` // Unique, applied in ascending order
+	Name    string                            `json:"name"`
+	Up      func(ctx IMigrationContext) error `json:"-"`
+}
+
+// IMigrationContext gives a running migration access to the database. In a dry run the
+// helper methods only record the actions they would take; code using DB() directly must
+// check DryRun() itself.
+type IMigrationContext interface {
+	DB() IRavenDBService
+	DryRun() bool
+
+	// Patch runs a JavaScript patch against every document in the collection
+	Patch(collection, script string) error
+	// PatchWhere runs a JavaScript patch against the documents matching an RQL where clause
+	PatchWhere(collection, whereClause, script string) error
+	// RenameField moves a top-level field on every document in the collection that has it
+	RenameField(collection, from, to string) error
+	// CreateIndex creates or updates a static index from map (and optional reduce) definitions
+	CreateIndex(name string, maps []string, reduce string) error
+	// Logf records a custom action in the migration report
+	Logf(format string, args ...interface{})
+}
+
+// MigrateOptions controls a migration run
+type MigrateOptions struct {
+	DryRun        bool          `json:"dryRun,omitempty"`        // Report pending migrations and their actions without changing anything
+	TargetVersion int64         `json:"targetVersion,omitempty"` // Highest version to apply, 0 for all
+	Owner         string        `json:"owner,omitempty"`         // Lock owner shown to other instances, defaults to host name and process ID
+	LockTTL       time.Duration `json:"lockTTL,omitempty"`       // Time without renewal after which a lock is considered abandoned, defaults to 15 minutes; held locks renew every third of it
+}
+
+// AppliedMigration records a migration that has been applied
+type AppliedMigration struct {
+	Version   int64         `json:"version"`
+	Name      string        `json:"name"`
+	AppliedAt time.Time     `json:"appliedAt"`
+	Duration  time.Duration `json:"duration"`
+}
+
+// MigrationInfo identifies a registered migration
+type MigrationInfo struct {
+	Version int64  `json:"version"`
+	Name    string `json:"name"`
+}
+
+// MigrationStatus describes applied and pending migrations
+type MigrationStatus struct {
+	CurrentVersion int64              `json:"currentVersion"` // Highest applied version, 0 if none
+	Applied        []AppliedMigration `json:"applied"`
+	Pending        []MigrationInfo    `json:"pending"`
+}
+
+// MigrationReport describes the outcome of a migration run
+type MigrationReport struct {
+	DryRun      bool               `json:"dryRun"`
+	FromVersion int64              `json:"fromVersion"`
+	ToVersion   int64              `json:"toVersion"`
+	Applied     []AppliedMigration `json:"applied"`           // Migrations applied, or that would be applied in a dry run
+	Actions     []string           `json:"actions,omitempty"` // Actions taken, or planned in a dry run
+}
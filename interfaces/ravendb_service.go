@@ -1,5 +1,7 @@
 package interfaces
 
+import "time"
+
 // QueryOptions provides flexible query configuration
 type QueryOptions struct {
 	Skip         int                    `json:"skip,omitempty"`
@@ -11,6 +13,60 @@ type QueryOptions struct {
 	IncludeTotal bool                   `json:"includeTotal,omitempty"`
 }
 
+// StoreOptions controls per-document metadata applied when storing a document
+type StoreOptions struct {
+	Expires   *time.Time    `json:"expires,omitempty"`   // Absolute time at which RavenDB deletes the document
+	ExpiresIn time.Duration `json:"expiresIn,omitempty"` // Relative expiration, used when Expires is nil
+	Refresh   *time.Time    `json:"refresh,omitempty"`   // Absolute time at which RavenDB refreshes the document
+	RefreshIn time.Duration `json:"refreshIn,omitempty"` // Relative refresh, used when Refresh is nil
+
+	Metadata map[string]interface{} `json:"metadata,omitempty"` // Custom metadata entries; keys must not start with "@"
+}
+
+// DeleteOptions makes deletes conditional on the documents' current change vectors. A mismatch,
+// or an expected change vector for a missing document, fails with ErrConcurrencyConflict.
+type DeleteOptions struct {
+	ChangeVector  string            `json:"changeVector,omitempty"`  // Expected change vector for a single delete
+	ChangeVectors map[string]string `json:"changeVectors,omitempty"` // Expected change vectors by ID for multiple deletes
+}
+
+// IDStrategy selects how a collection service generates IDs for documents stored without one
+type IDStrategy string
+
+const (
+	IDStrategyHiLo     IDStrategy = "hilo"     // users/17-A from ranges reserved by the client; the default
+	IDStrategyIdentity IDStrategy = "identity" // users/17 from the server's per-collection counter, assigned on save
+	IDStrategyGUID     IDStrategy = "guid"     // users/<random UUID>
+	IDStrategyULID     IDStrategy = "ulid"     // users/<ULID>, sortable by creation time
+)
+
+// CollectionOptions provides collection-level defaults for a collection service
+type CollectionOptions struct {
+	DefaultTTL  time.Duration                              `json:"defaultTTL,omitempty"` // Expiration applied to stored documents that set none explicitly
+	IDStrategy  IDStrategy                                 `json:"idStrategy,omitempty"` // Strategy for documents stored without an ID
+	IDGenerator func(document interface{}) (string, error) `json:"-"`                    // Custom IDs from the document being stored; takes precedence over IDStrategy
+	Validators  []func(document interface{}) error         `json:"-"`                    // Run after the document's Validate method before it is stored or updated
+}
+
+// DatabaseOptions configures the resilience behaviour of a database service
+type DatabaseOptions struct {
+	Retry          *RetryPolicy           `json:"retry,omitempty"`          // Nil disables retries
+	CircuitBreaker *CircuitBreakerOptions `json:"circuitBreaker,omitempty"` // Nil disables the circuit breaker
+}
+
+// ExpirationOptions configures the server-side document expiration feature
+type ExpirationOptions struct {
+	Disabled          bool          `json:"disabled"`
+	DeleteFrequency   time.Duration `json:"deleteFrequency,omitempty"` // Sent in whole seconds, rounded up; defaults to 60 seconds
+	MaxItemsToProcess int64         `json:"maxItemsToProcess,omitempty"`
+}
+
+// RefreshOptions configures the server-side document refresh feature
+type RefreshOptions struct {
+	Disabled         bool          `json:"disabled"`
+	RefreshFrequency time.Duration `json:"refreshFrequency,omitempty"` // Sent in whole seconds, rounded up; defaults to 60 seconds
+}
+
 // QueryResult contains paginated query results
 type QueryResult struct {
 	Results    []interface{} `json:"results"`
@@ -29,6 +85,46 @@ type GenericQueryResult[T any] struct {
 	HasMore    bool `json:"hasMore"`
 }
 
+// StoreResult reports the ID and change vector assigned to a stored document
+type StoreResult struct {
+	ID           string `json:"id"`
+	ChangeVector string `json:"changeVector"`
+}
+
+// LoadResult contains documents loaded by ID in the requested order and the IDs that do not exist
+type LoadResult[T any] struct {
+	Results []T      `json:"results"`
+	Missing []string `json:"missing,omitempty"`
+}
+
+// DocumentMetadata is the typed form of a document's @metadata
+type DocumentMetadata struct {
+	ID           string                 `json:"id"`
+	Collection   string                 `json:"collection,omitempty"`
+	ChangeVector string                 `json:"changeVector,omitempty"`
+	LastModified time.Time              `json:"lastModified"`
+	Flags        []string               `json:"flags,omitempty"`   // e.g. HasRevisions, HasAttachments
+	Expires      *time.Time             `json:"expires,omitempty"` // Set for documents with an expiration
+	Refresh      *time.Time             `json:"refresh,omitempty"` // Set for documents with a refresh time
+	Custom       map[string]interface{} `json:"custom,omitempty"`  // Entries whose keys do not start with "@", except the client's Raven-Go-Type
+}
+
+// Document pairs a loaded document with its metadata
+type Document[T any] struct {
+	Document T                `json:"document"`
+	Metadata DocumentMetadata `json:"metadata"`
+}
+
+// RawQueryResult contains the untyped results of an RQL query, each with its @metadata
+type RawQueryResult struct {
+	Results        []map[string]interface{} `json:"results"`
+	TotalResults   int                      `json:"totalResults"`
+	SkippedResults int                      `json:"skippedResults,omitempty"`
+	IsStale        bool                     `json:"isStale"`
+	IndexName      string                   `json:"indexName"`
+	DurationInMs   int64                    `json:"durationInMs"`
+}
+
 // IRavenDBService defines the comprehensive interface for RavenDB operations
 type IRavenDBService interface {
 	// Database lifecycle
@@ -36,21 +132,57 @@ type IRavenDBService interface {
 	InitializeWithSeeding(seedData bool) error
 	Close() error
 	GetDatabaseStatus() (map[string]interface{}, error)
+	GetStatus() (*DatabaseStatus, error)
+
+	// Data seeding
+	RegisterSeeder(seeder ISeeder)
+	GetAppliedSeeds() ([]SeedRecord, error)
+
+	// Migrations
+	RegisterMigration(migration Migration) error
+	Migrate(options *MigrateOptions) (*MigrationReport, error)
+	GetMigrationStatus() (*MigrationStatus, error)
+
+	// Backup, restore and smuggler transfers of the whole database
+	BackupDatabase(options *BackupOptions) (IRavenOperation, error)
+	RestoreDatabase(options *RestoreOptions) (IRavenOperation, error)
+	ExportDatabase(path string, options *SmugglerOptions) (IRavenOperation, error)
+	ImportDatabase(path string, options *SmugglerOptions) (IRavenOperation, error)
+	GetOperationState(id int64) (*OperationState, error)
+
+	// Index administration
+	GetIndexes() ([]IndexInfo, error)
+	ResetIndex(name string) error
+
+	// Expiration and refresh administration
+	ConfigureExpiration(options *ExpirationOptions) error
+	ConfigureRefresh(options *RefreshOptions) error
+
+	// Data subscriptions
+	CreateSubscription(options *SubscriptionOptions) (string, error)
+	UpdateSubscription(options *SubscriptionOptions) error
+	DeleteSubscription(name string) error
 
 	// Basic CRUD operations
-	Store(id string, document interface{}) error
+	Store(id string, document interface{}) (*StoreResult, error)
 	LoadByID(id string, result interface{}) error
 	Delete(id string) error
+	DeleteWithOptions(id string, options *DeleteOptions) error
 
 	// Enhanced CRUD operations
-	StoreMultiple(documents map[string]interface{}) error
+	StoreMultiple(documents map[string]interface{}) (map[string]*StoreResult, error)
 	LoadMultipleByIDs(ids []string, results interface{}) error
+	LoadMultipleByIDsWithMissing(ids []string, results interface{}) ([]string, error)
 	Update(id string, updates map[string]interface{}) error
 	DeleteMultiple(ids []string) error
+	DeleteMultipleWithOptions(ids []string, options *DeleteOptions) ([]string, error)
 
 	// Utility methods
 	Exists(id string) (bool, error)
+	ExistsWithChangeVector(id string) (string, error)
+	ExistsMany(ids []string) (map[string]string, error)
 	CountDocuments(collection string) (int, error)
+	CountDocumentsWithOptions(collection string, options *QueryOptions) (int, error)
 
 	// Additional database service specific methods
 	GetStore() interface{} // Returns the underlying DocumentStore as interface{}
@@ -60,13 +192,22 @@ type IRavenDBService interface {
 // IRavenCollectionService defines the interface for generic collection operations
 type IRavenCollectionService[T any] interface {
 	// CRUD Operations
-	Store(id string, document T) error
-	StoreMultiple(documents map[string]T) error
+	Store(id string, document *T) (*StoreResult, error)
+	StoreWithOptions(id string, document *T, options *StoreOptions) (*StoreResult, error)
+	StoreMultiple(documents map[string]*T) (map[string]*StoreResult, error)
+	Create(id string, document *T) (*StoreResult, error)
+	Replace(id string, document *T) (*StoreResult, error)
+	Upsert(id string, document *T) (*StoreResult, error)
 	LoadByID(id string) (*T, error)
 	LoadMultipleByIDs(ids []string) ([]T, error)
-	Update(id string, document T) error
+	LoadMultipleByIDsWithMissing(ids []string) (*LoadResult[T], error)
+	LoadWithMetadata(id string) (*Document[T], error)
+	LoadMultipleWithMetadata(ids []string) (*LoadResult[Document[T]], error)
+	Update(id string, document *T) error
 	Delete(id string) error
+	DeleteWithOptions(id string, options *DeleteOptions) error
 	DeleteMultiple(ids []string) error
+	DeleteMultipleWithOptions(ids []string, options *DeleteOptions) ([]string, error)
 
 	// Query Operations
 	Query(options *QueryOptions) (*GenericQueryResult[T], error)
@@ -77,5 +218,8 @@ type IRavenCollectionService[T any] interface {
 
 	// Utility Operations
 	Exists(id string) (bool, error)
+	ExistsWithChangeVector(id string) (string, error)
+	ExistsMany(ids []string) (map[string]string, error)
 	Count() (int, error)
+	CountWithOptions(options *QueryOptions) (int, error)
 }
@@ -0,0 +1,27 @@
+package interfaces
+
+import "time"
+
+// RetryPolicy controls how operations that fail with transient errors are retried
+type RetryPolicy struct {
+	MaxAttempts        int              `json:"maxAttempts"`                  // Total attempts including the first; 1 or less disables retries
+	InitialBackoff     time.Duration    `json:"initialBackoff,omitempty"`     // Delay before the first retry
+	MaxBackoff         time.Duration    `json:"maxBackoff,omitempty"`         // Upper bound for any single delay
+	Multiplier         float64          `json:"multiplier,omitempty"`         // Backoff growth factor per attempt, defaults to 2
+	Jitter             float64          `json:"jitter,omitempty"`             // Random spread as a fraction of the delay, 0 to 1
+	RetryOn            []error          `json:"-"`                            // Retryable error classes, defaults to ErrTimeout and ErrUnavailable
+	Retryable          func(error) bool `json:"-"`                            // Custom classifier, overrides RetryOn when set
+	RetryNonIdempotent bool             `json:"retryNonIdempotent,omitempty"` // Also retry writes that are unsafe to repeat, such as stores without an ID
+}
+
+// DefaultRetryPolicy returns a policy of three attempts with jittered exponential backoff
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		RetryOn:        []error{ErrTimeout, ErrUnavailable},
+	}
+}
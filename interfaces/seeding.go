@@ -0,0 +1,21 @@
+package interfaces
+
+import "time"
+
+// ISeeder populates a database with initial data when InitializeWithSeeding(true) runs
+type ISeeder interface {
+	// Name identifies the seed set; a seed set is applied at most once per database
+	Name() string
+	// Collection is the collection that must be empty for the seed to run; empty means the whole database
+	Collection() string
+	// Seed writes the seed documents and returns how many were written
+	Seed(db IRavenDBService) (int, error)
+}
+
+// SeedRecord describes a seed set that has been applied to the database
+type SeedRecord struct {
+	Name       string    `json:"name"`
+	Collection string    `json:"collection,omitempty"`
+	Documents  int       `json:"documents"`
+	AppliedAt  time.Time `json:"appliedAt"`
+}
@@ -0,0 +1,90 @@
+package interfaces
+
+import "time"
+
+// Database connection states reported in DatabaseStatus.Status
+const (
+	StatusConnected    = "connected"    // Server reachable and statistics loaded
+	StatusDisconnected = "disconnected" // Server or database could not be reached
+	StatusUnavailable  = "unavailable"  // Circuit breaker is open; the server was not contacted
+)
+
+// DatabaseStatus describes the state and statistics of a database
+type DatabaseStatus struct {
+	DatabaseName     string                `json:"databaseName"`
+	Status           string                `json:"status"`
+	Error            string                `json:"error,omitempty"`
+	DocumentCount    int64                 `json:"documentCount"`
+	IndexCount       int                   `json:"indexCount"`
+	AttachmentCount  int64                 `json:"attachmentCount"`
+	CounterCount     int64                 `json:"counterCount"`
+	RevisionCount    int64                 `json:"revisionCount"`
+	ConflictCount    int64                 `json:"conflictCount"`
+	TombstoneCount   int64                 `json:"tombstoneCount"`
+	StaleIndexes     []string              `json:"staleIndexes,omitempty"`
+	SizeOnDisk       int64                 `json:"sizeOnDisk"`       // Bytes
+	SizeOnDiskHumane string                `json:"sizeOnDiskHumane"` // e.g. "1.5 MBytes"
+	LastDocEtag      int64                 `json:"lastDocEtag"`
+	LastIndexingTime *time.Time            `json:"lastIndexingTime,omitempty"`
+	Collections      map[string]int64      `json:"collections,omitempty"` // Document count per collection
+	CircuitBreaker   *CircuitBreakerStatus `json:"circuitBreaker,omitempty"`
+}
+
+// CircuitBreakerStatus describes the state of a database service's circuit breaker
+type CircuitBreakerStatus struct {
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutiveFailures"`
+	OpenedAt            *time.Time   `json:"openedAt,omitempty"`
+	RetryAt             *time.Time   `json:"retryAt,omitempty"` // Earliest time a probe will be allowed
+}
+
+// ToMap returns the status in the map form returned by GetDatabaseStatus
+func (s *DatabaseStatus) ToMap() map[string]interface{} {
+	status := map[string]interface{}{
+		"database_name": s.DatabaseName,
+		"status":        s.Status,
+	}
+	if s.CircuitBreaker != nil {
+		status["circuit_breaker"] = s.CircuitBreaker.ToMap()
+	}
+	if s.Error != "" {
+		status["error"] = s.Error
+	}
+	if s.Status != StatusConnected {
+		return status
+	}
+
+	status["session_active"] = true
+	status["document_count"] = s.DocumentCount
+	status["index_count"] = s.IndexCount
+	status["attachment_count"] = s.AttachmentCount
+	status["counter_count"] = s.CounterCount
+	status["revision_count"] = s.RevisionCount
+	status["conflict_count"] = s.ConflictCount
+	status["tombstone_count"] = s.TombstoneCount
+	status["stale_indexes"] = s.StaleIndexes
+	status["size_on_disk"] = s.SizeOnDisk
+	status["size_on_disk_humane"] = s.SizeOnDiskHumane
+	status["last_doc_etag"] = s.LastDocEtag
+	status["collections"] = s.Collections
+	if s.LastIndexingTime != nil {
+		status["last_indexing_time"] = *s.LastIndexingTime
+	}
+
+	return status
+}
+
+// ToMap returns the circuit breaker status in map form
+func (s *CircuitBreakerStatus) ToMap() map[string]interface{} {
+	status := map[string]interface{}{
+		"state":                s.State,
+		"consecutive_failures": s.ConsecutiveFailures,
+	}
+	if s.OpenedAt != nil {
+		status["opened_at"] = *s.OpenedAt
+	}
+	if s.RetryAt != nil {
+		status["retry_at"] = *s.RetryAt
+	}
+	return status
+}
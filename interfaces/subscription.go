@@ -0,0 +1,61 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+)
+
+// Subscription starting points accepted by SubscriptionOptions.ChangeVector
+const (
+	SubscriptionBeginningOfTime = "BeginningOfTime"
+	SubscriptionLastDocument    = "LastDocument"
+	SubscriptionDoNotChange     = "DoNotChange"
+)
+
+// Subscription opening strategies accepted by SubscriptionWorkerOptions.Strategy
+const (
+	SubscriptionOpenIfFree  = "OpenIfFree"
+	SubscriptionTakeOver    = "TakeOver"
+	SubscriptionWaitForFree = "WaitForFree"
+)
+
+// SubscriptionOptions describes a data subscription definition
+type SubscriptionOptions struct {
+	Name         string `json:"name,omitempty"`
+	Query        string `json:"query"`                  // RQL filter, e.g. "from Orders where Total > 100"
+	ChangeVector string `json:"changeVector,omitempty"` // Starting point; defaults to the beginning of time
+	MentorNode   string `json:"mentorNode,omitempty"`
+}
+
+// SubscriptionWorkerOptions configures how a subscription worker consumes batches
+type SubscriptionWorkerOptions struct {
+	MaxDocsPerBatch    int           `json:"maxDocsPerBatch,omitempty"`    // Defaults to 4096
+	Concurrency        int           `json:"concurrency,omitempty"`        // Handler goroutines per batch, defaults to 1
+	RetryDelay         time.Duration `json:"retryDelay,omitempty"`         // Wait before reconnecting, defaults to 5s
+	MaxErroneousPeriod time.Duration `json:"maxErroneousPeriod,omitempty"` // Give up after failing this long, defaults to 5m
+	Strategy           string        `json:"strategy,omitempty"`           // Defaults to SubscriptionOpenIfFree
+}
+
+// SubscriptionItem is a single typed document delivered by a subscription
+type SubscriptionItem[T any] struct {
+	ID           string `json:"id"`
+	ChangeVector string `json:"changeVector"`
+	Document     T      `json:"document"`
+}
+
+// SubscriptionBatch contains the typed documents of one subscription batch
+type SubscriptionBatch[T any] struct {
+	Items []SubscriptionItem[T] `json:"items"`
+}
+
+// SubscriptionHandler processes a batch; returning nil acknowledges it, returning an error
+// leaves it unacknowledged so the server redelivers it after the worker reconnects
+type SubscriptionHandler[T any] func(ctx context.Context, batch *SubscriptionBatch[T]) error
+
+// IRavenSubscriptionWorker defines the interface for a typed subscription consumer
+type IRavenSubscriptionWorker[T any] interface {
+	// Run processes batches until ctx is cancelled, Close is called or the subscription fails
+	Run(ctx context.Context, handler SubscriptionHandler[T]) error
+	Close() error
+	GetSubscriptionName() string
+}
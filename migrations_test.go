@@ -0,0 +1,134 @@
+package ravendb
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// legacyUser is a document shape from before the fullName to name migration
+type legacyUser struct {
+	ID       string `json:"id"`
+	FullName string `json:"fullName"`
+}
+
+func TestMigrations(t *testing.T) {
+	testConfig, err := LoadTestConfig(testConfigPath)
+	require.NoError(t, err, "Failed to load test configuration")
+
+	// Applied versions persist in the database, so use a throwaway one
+	config := &Config{
+		URLs:     testConfig.Database.URLs,
+		Database: fmt.Sprintf("MigrationTest_%d", time.Now().UnixNano()),
+	}
+	db, err := NewDatabase(config)
+	require.NoError(t, err, "Failed to create database service")
+	defer func() {
+		NewAdmin(db).DeleteDatabase(config.Database, &interfaces.DatabaseDeleteOptions{HardDelete: true})
+		db.Close()
+	}()
+	require.NoError(t, db.Init(), "Failed to initialize database")
+
+	legacyUsers := NewCollection[legacyUser](db, "Users")
+	_, err = legacyUsers.Store("users/migrate-1", &legacyUser{ID: "users/migrate-1", FullName: "Migrated User"})
+	require.NoError(t, err)
+
+	require.NoError(t, db.RegisterMigration(interfaces.Migration{
+		Version: 1,
+		Name:    "rename fullName to name",
+		Up: func(ctx interfaces.IMigrationContext) error {
+			return ctx.RenameField("Users", "fullName", "name")
+		},
+	}))
+	require.NoError(t, db.RegisterMigration(interfaces.Migration{
+		Version: 2,
+		Name:    "index users by name",
+		Up: func(ctx interfaces.IMigrationContext) error {
+			return ctx.CreateIndex("Users/ByName", []string{"from u in docs.Users select new { u.name }"}, "")
+		},
+	}))
+
+	t.Run("Reject Duplicate Version", func(t *testing.T) {
+		err := db.RegisterMigration(interfaces.Migration{Version: 2, Name: "duplicate", Up: func(interfaces.IMigrationContext) error { return nil }})
+		assert.Error(t, err)
+	})
+
+	t.Run("Dry Run", func(t *testing.T) {
+		report, err := db.Migrate(&interfaces.MigrateOptions{DryRun: true})
+		require.NoError(t, err, "Dry run failed")
+		assert.True(t, report.DryRun)
+		assert.Len(t, report.Applied, 2)
+		assert.Len(t, report.Actions, 2)
+
+		status, err := db.GetMigrationStatus()
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), status.CurrentVersion)
+		assert.Len(t, status.Pending, 2, "Dry run must not record migrations")
+	})
+
+	t.Run("Apply", func(t *testing.T) {
+		report, err := db.Migrate(nil)
+		require.NoError(t, err, "Migration failed")
+		assert.Equal(t, int64(0), report.FromVersion)
+		assert.Equal(t, int64(2), report.ToVersion)
+
+		user, err := NewCollection[TestUser](db, "Users").LoadByID("users/migrate-1")
+		require.NoError(t, err)
+		assert.Equal(t, "Migrated User", user.Name)
+
+		legacy, err := legacyUsers.LoadByID("users/migrate-1")
+		require.NoError(t, err)
+		assert.Empty(t, legacy.FullName, "Old field should be removed")
+
+		status, err := db.GetMigrationStatus()
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), status.CurrentVersion)
+		assert.Len(t, status.Applied, 2)
+		assert.Empty(t, status.Pending)
+	})
+
+	t.Run("Cluster-Wide Lock", func(t *testing.T) {
+		// A migration that runs while the lock is held sees a concurrent run rejected
+		var concurrentErr error
+		require.NoError(t, db.RegisterMigration(interfaces.Migration{
+			Version: 3,
+			Name:    "concurrent run",
+			Up: func(ctx interfaces.IMigrationContext) error {
+				_, concurrentErr = ctx.DB().Migrate(nil)
+				return nil
+			},
+		}))
+
+		report, err := db.Migrate(nil)
+		require.NoError(t, err)
+		assert.Len(t, report.Applied, 1, "Only the new migration should run")
+		assert.ErrorIs(t, concurrentErr, interfaces.ErrMigrationLocked)
+
+		// The lock is released afterwards
+		_, err = db.Migrate(nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Lease Renewal", func(t *testing.T) {
+		// A migration outlasting the TTL keeps its lock because the lease is renewed
+		ttl := 300 * time.Millisecond
+		var lateErr error
+		require.NoError(t, db.RegisterMigration(interfaces.Migration{
+			Version: 4,
+			Name:    "long running",
+			Up: func(ctx interfaces.IMigrationContext) error {
+				time.Sleep(3 * ttl)
+				_, lateErr = ctx.DB().Migrate(&interfaces.MigrateOptions{LockTTL: ttl})
+				return nil
+			},
+		}))
+
+		_, err := db.Migrate(&interfaces.MigrateOptions{LockTTL: ttl})
+		require.NoError(t, err)
+		assert.ErrorIs(t, lateErr, interfaces.ErrMigrationLocked, "A renewed lock must not be taken over")
+	})
+}
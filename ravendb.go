@@ -3,13 +3,18 @@
 package ravendb
 
 import (
+	"io"
+
 	"github.com/ternarybob/ravendb/interfaces"
 	"github.com/ternarybob/ravendb/services"
 )
 
 // NewDatabase creates a new RavenDB database service using the provided configuration
 func NewDatabase(config *Config) (interfaces.IRavenDBService, error) {
-	return services.NewDatabaseService(config.URLs, config.Database)
+	return services.NewDatabaseServiceWithOptions(config.URLs, config.Database, &interfaces.DatabaseOptions{
+		Retry:          config.Retry,
+		CircuitBreaker: config.CircuitBreaker,
+	})
 }
 
 // NewCollection creates a new typed collection service for the specified document type
@@ -17,11 +22,82 @@ func NewCollection[T any](database interfaces.IRavenDBService, collectionName st
 	return services.NewCollectionService[T](database, collectionName)
 }
 
-// Query executes a generic query on the specified collection
+// NewCollectionWithOptions creates a new typed collection service with collection-level defaults such as a default TTL
+func NewCollectionWithOptions[T any](database interfaces.IRavenDBService, collectionName string, options *interfaces.CollectionOptions) interfaces.IRavenCollectionService[T] {
+	return services.NewCollectionServiceWithOptions[T](database, collectionName, options)
+}
+
+// NewCollectionFor creates a new typed collection service whose collection name is derived from the
+// document type: its CollectionName method, a ravendb:"collection=..." tag option, or the pluralized type name
+func NewCollectionFor[T any](database interfaces.IRavenDBService, options *interfaces.CollectionOptions) interfaces.IRavenCollectionService[T] {
+	return services.NewCollectionServiceWithOptions[T](database, "", options)
+}
+
+// CollectionName returns the collection name derived from the document type T
+func CollectionName[T any]() string {
+	return services.CollectionNameFor[T]()
+}
+
+// NewSubscriptionWorker creates a typed worker that consumes an existing data subscription
+func NewSubscriptionWorker[T any](database interfaces.IRavenDBService, subscriptionName string, options *interfaces.SubscriptionWorkerOptions) interfaces.IRavenSubscriptionWorker[T] {
+	return services.NewSubscriptionWorker[T](database, subscriptionName, options)
+}
+
+// NewChanges creates a service that delivers document and index change notifications on typed channels
+func NewChanges(database interfaces.IRavenDBService, options *interfaces.ChangesOptions) interfaces.IRavenChangesService {
+	return services.NewChangesService(database, options)
+}
+
+// NewSeeder creates a seeder that runs seed when the collection (or the database, if empty) has no documents
+func NewSeeder(name, collection string, seed func(db interfaces.IRavenDBService) (int, error)) interfaces.ISeeder {
+	return services.NewSeeder(name, collection, seed)
+}
+
+// NewFixtureSeeder creates a seeder that loads a JSON or NDJSON fixture file into an empty collection
+func NewFixtureSeeder(name, collection, path string) interfaces.ISeeder {
+	return services.NewFixtureSeeder(name, collection, path)
+}
+
+// LoadFixture bulk-inserts the documents in a JSON or NDJSON fixture file into a collection
+func LoadFixture(db interfaces.IRavenDBService, collection, path string) (int, error) {
+	return services.LoadFixture(db, collection, path)
+}
+
+// Export streams documents from one or more collections to w as NDJSON, with their metadata
+func Export(db interfaces.IRavenDBService, w io.Writer, options *interfaces.ExportOptions) (*interfaces.TransferResult, error) {
+	return services.Export(db, w, options)
+}
+
+// ExportToFile writes documents from one or more collections to an NDJSON file
+func ExportToFile(db interfaces.IRavenDBService, path string, options *interfaces.ExportOptions) (*interfaces.TransferResult, error) {
+	return services.ExportToFile(db, path, options)
+}
+
+// Import bulk-inserts NDJSON documents written by Export from r
+func Import(db interfaces.IRavenDBService, r io.Reader, options *interfaces.ImportOptions) (*interfaces.TransferResult, error) {
+	return services.Import(db, r, options)
+}
+
+// ImportFromFile bulk-inserts the documents in an NDJSON file written by ExportToFile
+func ImportFromFile(db interfaces.IRavenDBService, path string, options *interfaces.ImportOptions) (*interfaces.TransferResult, error) {
+	return services.ImportFromFile(db, path, options)
+}
+
+// NewAdmin creates a service for server-level database administration over the database's connection
+func NewAdmin(database interfaces.IRavenDBService) interfaces.IRavenAdminService {
+	return services.NewAdminService(database)
+}
+
+// Query executes a generic query on the specified collection, or the one derived from T when empty
 func Query[T any](service interfaces.IRavenDBService, collection string, options *interfaces.QueryOptions) (*interfaces.GenericQueryResult[T], error) {
 	return services.Query[T](service, collection, options)
 }
 
+// RawQuery executes an RQL query and returns the untyped results with their metadata
+func RawQuery(service interfaces.IRavenDBService, rql string, parameters map[string]interface{}) (*interfaces.RawQueryResult, error) {
+	return services.RawQuery(service, rql, parameters)
+}
+
 // QueryAll queries all documents in the specified collection
 func QueryAll[T any](service interfaces.IRavenDBService, collection string) (*interfaces.GenericQueryResult[T], error) {
 	return services.QueryAll[T](service, collection)
@@ -0,0 +1,115 @@
+package ravendb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+func TestDataSeeding(t *testing.T) {
+	testConfig, err := LoadTestConfig(testConfigPath)
+	require.NoError(t, err, "Failed to load test configuration")
+
+	// Seeding needs an empty database, so use a throwaway one
+	config := &Config{
+		URLs:     testConfig.Database.URLs,
+		Database: fmt.Sprintf("SeedTest_%d", time.Now().UnixNano()),
+	}
+	db, err := NewDatabase(config)
+	require.NoError(t, err, "Failed to create database service")
+	defer func() {
+		NewAdmin(db).DeleteDatabase(config.Database, &interfaces.DatabaseDeleteOptions{HardDelete: true})
+		db.Close()
+	}()
+
+	dir := t.TempDir()
+	usersPath := filepath.Join(dir, "users.ndjson")
+	productsPath := filepath.Join(dir, "products.json")
+	require.NoError(t, os.WriteFile(usersPath, []byte(
+		`{"id": "users/seed-1", "name": "Seed One", "age": 30}`+"\n"+
+			`{"id": "users/seed-2", "name": "Seed Two", "age": 40}`+"\n"), 0o644))
+	require.NoError(t, os.WriteFile(productsPath, []byte(
+		`[{"name": "Widget", "price": 9.99}, {"name": "Gadget", "price": 19.99}, {"name": "Gizmo", "price": 4.5}]`), 0o644))
+
+	t.Run("Apply Seeds", func(t *testing.T) {
+		db.RegisterSeeder(NewFixtureSeeder("users-fixture", "Users", usersPath))
+		db.RegisterSeeder(NewFixtureSeeder("products-fixture", "Products", productsPath))
+
+		require.NoError(t, db.InitializeWithSeeding(true), "Failed to initialize with seeding")
+
+		users, err := db.CountDocuments("Users")
+		require.NoError(t, err)
+		assert.Equal(t, 2, users)
+
+		products, err := db.CountDocuments("Products")
+		require.NoError(t, err)
+		assert.Equal(t, 3, products)
+
+		exists, err := db.Exists("products/2")
+		require.NoError(t, err)
+		assert.True(t, exists, "Documents without an ID get positional IDs")
+	})
+
+	t.Run("Record Applied Seeds", func(t *testing.T) {
+		seeds, err := db.GetAppliedSeeds()
+		require.NoError(t, err)
+		require.Len(t, seeds, 2)
+		assert.Equal(t, "users-fixture", seeds[0].Name)
+		assert.Equal(t, 2, seeds[0].Documents)
+		assert.Equal(t, "products-fixture", seeds[1].Name)
+	})
+
+	t.Run("Skip Non-Empty Targets", func(t *testing.T) {
+		ran := false
+		db.RegisterSeeder(NewSeeder("more-users", "Users", func(db interfaces.IRavenDBService) (int, error) {
+			ran = true
+			return 0, nil
+		}))
+
+		require.NoError(t, db.InitializeWithSeeding(true), "Failed to re-run seeding")
+		assert.False(t, ran, "Seeder should not run against a populated collection")
+
+		seeds, err := db.GetAppliedSeeds()
+		require.NoError(t, err)
+		assert.Len(t, seeds, 2, "Applied seeds should not be repeated")
+	})
+
+	t.Run("Concurrent Instances Keep Each Record", func(t *testing.T) {
+		// Two instances recording seeds at once must not overwrite each other's registry writes
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for i, collection := range []string{"Orders", "Invoices"} {
+			instance, err := NewDatabase(config)
+			require.NoError(t, err)
+			defer instance.Close()
+			instance.RegisterSeeder(NewSeeder(collection+"-seed", collection, func(db interfaces.IRavenDBService) (int, error) {
+				return 0, nil
+			}))
+
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs[i] = instance.InitializeWithSeeding(true)
+			}(i)
+		}
+		wg.Wait()
+		require.NoError(t, errs[0])
+		require.NoError(t, errs[1])
+
+		seeds, err := db.GetAppliedSeeds()
+		require.NoError(t, err)
+		names := make([]string, 0, len(seeds))
+		for _, seed := range seeds {
+			names = append(names, seed.Name)
+		}
+		assert.Contains(t, names, "Orders-seed")
+		assert.Contains(t, names, "Invoices-seed")
+	})
+}
@@ -0,0 +1,349 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// databaseNamesPageSize is the number of names requested per page when listing databases
+const databaseNamesPageSize = 1024
+
+// AdminService performs server-level database administration through a database service's store
+type AdminService struct {
+	database interfaces.IRavenDBService
+}
+
+// NewAdminService creates an admin service sharing the database service's connection and resilience policy
+func NewAdminService(database interfaces.IRavenDBService) interfaces.IRavenAdminService {
+	return &AdminService{database: database}
+}
+
+// ListDatabases returns the names of all databases on the server
+func (as *AdminService) ListDatabases() ([]string, error) {
+	var names []string
+	for start := 0; ; start += databaseNamesPageSize {
+		command := ravendb.NewGetDatabaseNamesCommand(start, databaseNamesPageSize)
+		err := runOperation(as.database, true, func() error {
+			return as.server().Send(&serverCommandOperation{command: command})
+		})
+		if err != nil {
+			return nil, newOperationError("failed to list databases", "", "", "", err)
+		}
+
+		names = append(names, command.Result...)
+		if len(command.Result) < databaseNamesPageSize {
+			return names, nil
+		}
+	}
+}
+
+// DatabaseExists reports whether a database with the given name exists
+func (as *AdminService) DatabaseExists(name string) (bool, error) {
+	operation := ravendb.NewGetDatabaseRecordOperation(name)
+	err := runOperation(as.database, true, func() error {
+		return as.server().Send(operation)
+	})
+	if err != nil {
+		if errors.Is(classifyError(err), interfaces.ErrDatabaseDoesNotExist) {
+			return false, nil
+		}
+		return false, newOperationError("failed to check database "+name, "", "", "", err)
+	}
+
+	return operation.Command.Result != nil, nil
+}
+
+// CreateDatabase creates a database, failing with interfaces.ErrDatabaseAlreadyExists if it exists
+func (as *AdminService) CreateDatabase(options *interfaces.DatabaseCreateOptions) error {
+	if options == nil || options.Name == "" {
+		return fmt.Errorf("database name is required")
+	}
+
+	record := ravendb.NewDatabaseRecord()
+	record.DatabaseName = options.Name
+	record.Disabled = options.Disabled
+	record.Encrypted = options.Encrypted
+	record.DataDirectory = options.DataDirectory
+	for key, value := range options.Settings {
+		record.Settings[key] = value
+	}
+
+	replicationFactor := options.ReplicationFactor
+	if len(options.Members) > 0 {
+		if replicationFactor <= 0 {
+			replicationFactor = len(options.Members)
+		}
+		record.DatabaseTopology = &ravendb.DatabaseTopology{
+			Members:           options.Members,
+			ReplicationFactor: replicationFactor,
+		}
+	}
+	if replicationFactor <= 0 {
+		replicationFactor = 1
+	}
+
+	// A create that timed out may still have succeeded, in which case a retry would report our own database as existing
+	err := runOperation(as.database, false, func() error {
+		return as.server().Send(ravendb.NewCreateDatabaseOperation(record, replicationFactor))
+	})
+	if err != nil {
+		if isAlreadyExists(err) {
+			return &interfaces.OperationError{
+				Op:   "failed to create database " + options.Name,
+				Kind: interfaces.ErrDatabaseAlreadyExists,
+				Err:  err,
+			}
+		}
+		return newOperationError("failed to create database "+options.Name, "", "", "", err)
+	}
+
+	return nil
+}
+
+// DeleteDatabase deletes a database, optionally removing its data files
+func (as *AdminService) DeleteDatabase(name string, options *interfaces.DatabaseDeleteOptions) error {
+	if options == nil {
+		options = &interfaces.DatabaseDeleteOptions{}
+	}
+
+	parameters := &deleteDatabaseParameters{
+		DatabaseNames: []string{name},
+		HardDelete:    options.HardDelete,
+		FromNodes:     options.FromNodes,
+	}
+	if options.TimeToWaitForConfirmation > 0 {
+		parameters.TimeToWaitForConfirmation = formatTimeSpan(options.TimeToWaitForConfirmation)
+	}
+
+	err := runOperation(as.database, true, func() error {
+		return as.server().Send(&deleteDatabaseOperation{parameters: parameters})
+	})
+	if err != nil {
+		return newOperationError("failed to delete database "+name, "", "", "", err)
+	}
+
+	return nil
+}
+
+// EnableDatabase brings a disabled database back online
+func (as *AdminService) EnableDatabase(name string) error {
+	return as.toggleDatabase(name, false)
+}
+
+// DisableDatabase takes a database offline without deleting it
+func (as *AdminService) DisableDatabase(name string) error {
+	return as.toggleDatabase(name, true)
+}
+
+// EnsureDatabase creates the database unless it already exists, reporting whether it was created.
+// Genuine failures such as authorization errors are returned rather than treated as "exists".
+func (as *AdminService) EnsureDatabase(options *interfaces.DatabaseCreateOptions) (bool, error) {
+	if options == nil || options.Name == "" {
+		return false, fmt.Errorf("database name is required")
+	}
+
+	exists, err := as.DatabaseExists(options.Name)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return false, nil
+	}
+
+	// Another client may create the database between the check and the create
+	if err := as.CreateDatabase(options); err != nil {
+		if errors.Is(err, interfaces.ErrDatabaseAlreadyExists) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// toggleDatabase enables or disables a database
+func (as *AdminService) toggleDatabase(name string, disable bool) error {
+	action := "enable"
+	if disable {
+		action = "disable"
+	}
+
+	operation := &toggleDatabaseOperation{name: name, disable: disable}
+	err := runOperation(as.database, true, func() error {
+		return as.server().Send(operation)
+	})
+	if err != nil {
+		return newOperationError(fmt.Sprintf("failed to %s database %s", action, name), "", "", "", err)
+	}
+
+	for _, status := range operation.command.result.Status {
+		if strings.EqualFold(status.Name, name) && !status.Success {
+			return &interfaces.OperationError{
+				Op:  fmt.Sprintf("failed to %s database %s", action, name),
+				Err: errors.New(status.Reason),
+			}
+		}
+	}
+
+	return nil
+}
+
+// server returns the store's server-level operation executor
+func (as *AdminService) server() *ravendb.ServerOperationExecutor {
+	return as.database.GetStore().(*ravendb.DocumentStore).Maintenance().Server()
+}
+
+// isAlreadyExists reports whether a create failed because the database exists; the server
+// answers with a 409 that the client surfaces as a ConcurrencyError
+func isAlreadyExists(err error) bool {
+	var concurrencyErr *ravendb.ConcurrencyError
+	return errors.As(err, &concurrencyErr) && strings.Contains(strings.ToLower(err.Error()), "already exists")
+}
+
+// serverCommandOperation adapts a command to IServerOperation for the server-level executor,
+// for commands such as GetDatabaseNamesCommand whose operation types do not implement it in the Go client
+type serverCommandOperation struct {
+	command ravendb.RavenCommand
+}
+
+// GetCommand returns the wrapped command
+func (o *serverCommandOperation) GetCommand(conventions *ravendb.DocumentConventions) (ravendb.RavenCommand, error) {
+	return o.command, nil
+}
+
+// deleteDatabaseParameters mirrors the server's DeleteDatabasesOperation.Parameters record
+type deleteDatabaseParameters struct {
+	DatabaseNames             []string `json:"DatabaseNames"`
+	HardDelete                bool     `json:"HardDelete"`
+	FromNodes                 []string `json:"FromNodes,omitempty"`
+	TimeToWaitForConfirmation string   `json:"TimeToWaitForConfirmation,omitempty"`
+}
+
+// deleteDatabaseOperation deletes databases; unlike the client's operation it sends the
+// confirmation timeout as a TimeSpan string rather than Go nanoseconds
+type deleteDatabaseOperation struct {
+	parameters *deleteDatabaseParameters
+}
+
+// GetCommand returns the command that deletes the databases
+func (o *deleteDatabaseOperation) GetCommand(conventions *ravendb.DocumentConventions) (ravendb.RavenCommand, error) {
+	data, err := json.Marshal(o.parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize delete parameters: %w", err)
+	}
+
+	cmd := &deleteDatabaseCommand{
+		RavenCommandBase: ravendb.NewRavenCommandBase(),
+		parameters:       data,
+	}
+	cmd.ResponseType = ravendb.RavenCommandResponseTypeObject
+	return cmd, nil
+}
+
+type deleteDatabaseCommand struct {
+	ravendb.RavenCommandBase
+	parameters []byte
+}
+
+func (c *deleteDatabaseCommand) CreateRequest(node *ravendb.ServerNode) (*http.Request, error) {
+	request, err := http.NewRequest(http.MethodDelete, node.URL+"/admin/databases", bytes.NewReader(c.parameters))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	return request, nil
+}
+
+func (c *deleteDatabaseCommand) SetResponse(response []byte, fromCache bool) error {
+	return nil
+}
+
+// formatTimeSpan formats a duration in the .NET TimeSpan form [-][d.]hh:mm:ss[.fffffff], e.g.
+// "00:00:10" or "1.01:00:00.5000000"; the fraction is in 100ns ticks
+func formatTimeSpan(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	days := int64(d / (24 * time.Hour))
+	hours := int64(d % (24 * time.Hour) / time.Hour)
+	minutes := int64(d % time.Hour / time.Minute)
+	seconds := int64(d % time.Minute / time.Second)
+	ticks := int64(d % time.Second / 100)
+
+	var b strings.Builder
+	b.WriteString(sign)
+	if days > 0 {
+		fmt.Fprintf(&b, "%d.", days)
+	}
+	fmt.Fprintf(&b, "%02d:%02d:%02d", hours, minutes, seconds)
+	if ticks > 0 {
+		fmt.Fprintf(&b, ".%07d", ticks)
+	}
+	return b.String()
+}
+
+// toggleDatabaseOperation enables or disables a database; the Go client has no built-in equivalent
+type toggleDatabaseOperation struct {
+	name    string
+	disable bool
+	command *toggleDatabaseCommand
+}
+
+// GetCommand returns the command that changes the database state
+func (o *toggleDatabaseOperation) GetCommand(conventions *ravendb.DocumentConventions) (ravendb.RavenCommand, error) {
+	data, err := json.Marshal(map[string][]string{"DatabaseNames": {o.name}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize database state parameters: %w", err)
+	}
+
+	o.command = &toggleDatabaseCommand{
+		RavenCommandBase: ravendb.NewRavenCommandBase(),
+		disable:          o.disable,
+		parameters:       data,
+	}
+	o.command.ResponseType = ravendb.RavenCommandResponseTypeObject
+	return o.command, nil
+}
+
+// toggleDatabaseResult mirrors the server's DisableDatabaseToggleResult list
+type toggleDatabaseResult struct {
+	Status []struct {
+		Name     string `json:"Name"`
+		Success  bool   `json:"Success"`
+		Disabled bool   `json:"Disabled"`
+		Reason   string `json:"Reason"`
+	} `json:"Status"`
+}
+
+type toggleDatabaseCommand struct {
+	ravendb.RavenCommandBase
+	disable    bool
+	parameters []byte
+	result     toggleDatabaseResult
+}
+
+func (c *toggleDatabaseCommand) CreateRequest(node *ravendb.ServerNode) (*http.Request, error) {
+	url := node.URL + "/admin/databases/enable"
+	if c.disable {
+		url = node.URL + "/admin/databases/disable"
+	}
+	return ravendb.NewHttpPost(url, c.parameters)
+}
+
+func (c *toggleDatabaseCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return nil
+	}
+	return json.Unmarshal(response, &c.result)
+}
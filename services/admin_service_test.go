@@ -0,0 +1,23 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatTimeSpan(t *testing.T) {
+	cases := map[time.Duration]string{
+		0:                                  "00:00:00",
+		10 * time.Second:                   "00:00:10",
+		90 * time.Minute:                   "01:30:00",
+		25 * time.Hour:                     "1.01:00:00",
+		1500 * time.Millisecond:            "00:00:01.5000000",
+		48*time.Hour + 250*time.Nanosecond: "2.00:00:00.0000002",
+		-30 * time.Second:                  "-00:00:30",
+	}
+	for duration, expected := range cases {
+		assert.Equal(t, expected, formatTimeSpan(duration), "formatting %s", duration)
+	}
+}
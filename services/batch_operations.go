@@ -0,0 +1,190 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// deleteDocuments deletes documents by ID in one transaction without loading them, returning the IDs
+// that existed. IDs with an expected change vector are only deleted if it matches; otherwise the
+// whole batch fails with ErrConcurrencyConflict.
+func deleteDocuments(db interfaces.IRavenDBService, ids []string, changeVectors map[string]string) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	commands := make([]batchCommandData, 0, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return nil, fmt.Errorf("document ID cannot be empty")
+		}
+		command := batchCommandData{Type: "DELETE", ID: id}
+		if changeVector := changeVectors[id]; changeVector != "" {
+			command.ChangeVector = &changeVector
+		}
+		commands = append(commands, command)
+	}
+
+	// Deleting again is harmless, so the batch is retried
+	results, err := executeBatch(db, commands, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for i, result := range results {
+		if existed, _ := result["Deleted"].(bool); existed && i < len(ids) {
+			deleted = append(deleted, ids[i])
+		}
+	}
+	return deleted, nil
+}
+
+// putDocument writes a raw document under its ID. A non-nil change vector makes the write
+// conditional: an empty one requires that the document does not exist yet, any other one that
+// it is the document's current change vector.
+func putDocument(db interfaces.IRavenDBService, id string, document map[string]interface{}, changeVector *string) (map[string]interface{}, error) {
+	if id == "" {
+		return nil, fmt.Errorf("document ID cannot be empty")
+	}
+
+	// A repeated conditional write fails once the first one succeeded
+	results, err := executeBatch(db, []batchCommandData{{Type: "PUT", ID: id, ChangeVector: changeVector, Document: document}}, changeVector == nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("empty batch result")
+	}
+	return results[0], nil
+}
+
+// putResult reads the ID and change vector from a PUT batch result and writes the ID back into the entity's ID field
+func putResult(entity interface{}, result map[string]interface{}) *interfaces.StoreResult {
+	stored := &interfaces.StoreResult{}
+	stored.ID, _ = result[ravendb.MetadataID].(string)
+	stored.ChangeVector, _ = result[ravendb.MetadataChangeVector].(string)
+	setDocumentID(entity, stored.ID)
+	return stored
+}
+
+// entityDocument converts an entity to the raw document stored in the collection, with the custom
+// metadata from the options and the expiration resolved from them and the collection's default TTL.
+// Structs are serialized the way a session stores them: with their Go type in the metadata and
+// without the identity property, so session loads treat both writes alike.
+func entityDocument(entity interface{}, collection string, options *interfaces.StoreOptions, defaultTTL time.Duration) (map[string]interface{}, error) {
+	var document map[string]interface{}
+	if err := remarshal(entity, &document); err != nil {
+		return nil, fmt.Errorf("failed to serialize document: %w", err)
+	}
+	if document == nil {
+		return nil, fmt.Errorf("document must serialize to a JSON object")
+	}
+
+	metadata, _ := document[ravendb.MetadataKey].(map[string]interface{})
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	if goType := goTypeName(entity); goType != "" {
+		metadata[ravendb.MetadataRavenGoType] = goType
+		delete(document, ravendb.IdentityProperty)
+	}
+	if collection != "" {
+		metadata[ravendb.MetadataCollection] = collection
+	}
+	if options != nil {
+		if err := validateCustomMetadata(options.Metadata); err != nil {
+			return nil, err
+		}
+		for key, value := range options.Metadata {
+			metadata[key] = value
+		}
+	}
+
+	expires, refresh := resolveExpiration(options, defaultTTL, time.Now())
+	if expires != nil {
+		metadata[ravendb.MetadataExpires] = ravendb.Time(expires.UTC()).Format()
+	}
+	if refresh != nil {
+		metadata[metadataRefresh] = ravendb.Time(refresh.UTC()).Format()
+	}
+
+	document[ravendb.MetadataKey] = metadata
+	return document, nil
+}
+
+// goTypeName returns the package-qualified type name a session records for a struct entity,
+// or an empty string for maps, which sessions store as-is
+func goTypeName(entity interface{}) string {
+	typ := reflect.TypeOf(entity)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return ""
+	}
+	return typ.String()
+}
+
+// executeBatch sends commands to the server as one transaction and returns the per-command results
+func executeBatch(db interfaces.IRavenDBService, commands []batchCommandData, idempotent bool) ([]map[string]interface{}, error) {
+	data, err := json.Marshal(batchParameters{Commands: commands})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize batch: %w", err)
+	}
+
+	command := &batchCommand{RavenCommandBase: ravendb.NewRavenCommandBase(), parameters: data}
+	err = runOperation(db, idempotent, func() error {
+		store := db.GetStore().(*ravendb.DocumentStore)
+		return store.GetRequestExecutor(db.GetDatabase()).ExecuteCommand(command, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return command.results, nil
+}
+
+// batchParameters mirrors the server's batch request body
+type batchParameters struct {
+	Commands []batchCommandData `json:"Commands"`
+}
+
+// batchCommandData is a single command of a batch
+type batchCommandData struct {
+	Type         string                 `json:"Type"`
+	ID           string                 `json:"Id"`
+	ChangeVector *string                `json:"ChangeVector"`
+	Document     map[string]interface{} `json:"Document,omitempty"`
+}
+
+// batchCommand posts commands to /bulk_docs; the client's own batch command is unexported
+type batchCommand struct {
+	ravendb.RavenCommandBase
+	parameters []byte
+	results    []map[string]interface{}
+}
+
+func (c *batchCommand) CreateRequest(node *ravendb.ServerNode) (*http.Request, error) {
+	return ravendb.NewHttpPost(node.URL+"/databases/"+node.Database+"/bulk_docs", c.parameters)
+}
+
+func (c *batchCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return fmt.Errorf("empty batch response")
+	}
+
+	var result struct {
+		Results []map[string]interface{} `json:"Results"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return err
+	}
+	c.results = result.Results
+	return nil
+}
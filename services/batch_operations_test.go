@@ -0,0 +1,45 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+type sessionEntity struct {
+	ID   string
+	Name string `json:"name"`
+}
+
+func TestEntityDocument(t *testing.T) {
+	t.Run("Struct Matches Session Serialization", func(t *testing.T) {
+		document, err := entityDocument(&sessionEntity{ID: "entities/1", Name: "Ada"}, "Entities", &interfaces.StoreOptions{
+			Metadata: map[string]interface{}{"tenantId": "acme"},
+		}, 0)
+		require.NoError(t, err)
+
+		assert.NotContains(t, document, ravendb.IdentityProperty, "The identity property is not stored")
+		assert.Equal(t, "Ada", document["name"])
+		assert.Equal(t, map[string]interface{}{
+			ravendb.MetadataCollection:  "Entities",
+			ravendb.MetadataRavenGoType: "services.sessionEntity",
+			"tenantId":                  "acme",
+		}, document[ravendb.MetadataKey])
+	})
+
+	t.Run("Map Is Stored As-Is", func(t *testing.T) {
+		document, err := entityDocument(map[string]interface{}{"ID": "kept", "name": "Ada"}, "Entities", nil, 0)
+		require.NoError(t, err)
+
+		assert.Equal(t, "kept", document["ID"])
+		assert.Equal(t, map[string]interface{}{ravendb.MetadataCollection: "Entities"}, document[ravendb.MetadataKey])
+	})
+
+	t.Run("Rejects Non-Objects", func(t *testing.T) {
+		_, err := entityDocument([]string{"a"}, "Entities", nil, 0)
+		assert.Error(t, err)
+	})
+}
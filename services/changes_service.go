@@ -0,0 +1,435 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// ChangesService delivers document and index change notifications over typed channels.
+// It uses the store's changes connection for the database, which is shared with every other
+// changes service and client caller, and re-registers its subscriptions after a reconnect.
+type ChangesService struct {
+	database interfaces.IRavenDBService
+	options  interfaces.ChangesOptions
+
+	mu            sync.Mutex
+	connection    *changesConnection
+	subscriptions map[changeRegistration]struct{}
+	closed        bool
+	done          chan struct{}
+}
+
+// changesConnection is the service's use of a shared client connection: whether it has been
+// established and the handlers the service registered on it
+type changesConnection struct {
+	shared        *sharedChanges
+	changes       *ravendb.DatabaseChanges
+	connected     atomic.Bool
+	errorHandler  int
+	statusHandler int
+}
+
+// release removes the service's handlers from the shared connection, leaving it open
+func (c *changesConnection) release() {
+	c.changes.RemoveOnError(c.errorHandler)
+	c.changes.RemoveConnectionStatusChanged(c.statusHandler)
+	c.shared.release()
+}
+
+// sharedChanges is what the changes services using one client connection know about it:
+// how many of them use it and whether one has closed it after it failed
+type sharedChanges struct {
+	changes *ravendb.DatabaseChanges
+	users   int
+	closed  atomic.Bool
+}
+
+// sharedConnections holds the client connections in use by changes services. A connection is
+// forgotten once its last service releases it, so closed connections are not kept.
+var sharedConnections = struct {
+	sync.Mutex
+	byChanges map[*ravendb.DatabaseChanges]*sharedChanges
+}{byChanges: make(map[*ravendb.DatabaseChanges]*sharedChanges)}
+
+// acquireChanges registers a service as a user of a client connection, refusing one that a
+// service has closed but the store still hands out because closing has not finished
+func acquireChanges(changes *ravendb.DatabaseChanges) (*sharedChanges, error) {
+	sharedConnections.Lock()
+	defer sharedConnections.Unlock()
+
+	shared := sharedConnections.byChanges[changes]
+	if shared == nil {
+		shared = &sharedChanges{changes: changes}
+		sharedConnections.byChanges[changes] = shared
+	}
+	if shared.closed.Load() {
+		return nil, fmt.Errorf("failed to connect to changes API: previous connection is still closing")
+	}
+	shared.users++
+	return shared, nil
+}
+
+// release removes a service from the connection's users, forgetting the connection after the last
+func (s *sharedChanges) release() {
+	sharedConnections.Lock()
+	defer sharedConnections.Unlock()
+
+	if s.users--; s.users == 0 {
+		delete(sharedConnections.byChanges, s.changes)
+	}
+}
+
+// closeFailed closes a failed shared connection, which the client cannot reconnect by itself.
+// The first service to notice the failure closes it; closing removes it from the store, so the
+// next Changes call opens a new connection that the services share again. The caller must
+// still be a user, so the connection stays known as closed until closing has finished.
+func (s *sharedChanges) closeFailed() {
+	if s.closed.CompareAndSwap(false, true) {
+		s.changes.Close()
+	}
+}
+
+// changeRegistration is a subscription that can be (re)attached to a connection
+type changeRegistration interface {
+	attach(changes *ravendb.DatabaseChanges) error
+	detach()
+	Close() error
+}
+
+// NewChangesService creates a changes service for the database
+func NewChangesService(database interfaces.IRavenDBService, options *interfaces.ChangesOptions) interfaces.IRavenChangesService {
+	cs := &ChangesService{
+		database:      database,
+		subscriptions: make(map[changeRegistration]struct{}),
+		done:          make(chan struct{}),
+	}
+	if options != nil {
+		cs.options = *options
+	}
+	if cs.options.BufferSize <= 0 {
+		cs.options.BufferSize = 100
+	}
+	if cs.options.RetryDelay <= 0 {
+		cs.options.RetryDelay = time.Second
+	}
+	return cs
+}
+
+// ForDocument subscribes to changes of a single document
+func (cs *ChangesService) ForDocument(id string) (interfaces.IRavenChangeSubscription[interfaces.DocumentChangeEvent], error) {
+	return subscribeChanges(cs, func(changes *ravendb.DatabaseChanges, deliver func(interfaces.DocumentChangeEvent)) (ravendb.CancelFunc, error) {
+		return changes.ForDocument(id, documentChangeHandler(deliver))
+	})
+}
+
+// ForDocumentsStartingWith subscribes to changes of documents whose ID starts with prefix
+func (cs *ChangesService) ForDocumentsStartingWith(prefix string) (interfaces.IRavenChangeSubscription[interfaces.DocumentChangeEvent], error) {
+	return subscribeChanges(cs, func(changes *ravendb.DatabaseChanges, deliver func(interfaces.DocumentChangeEvent)) (ravendb.CancelFunc, error) {
+		return changes.ForDocumentsStartingWith(prefix, documentChangeHandler(deliver))
+	})
+}
+
+// ForCollection subscribes to changes of documents in a collection
+func (cs *ChangesService) ForCollection(collection string) (interfaces.IRavenChangeSubscription[interfaces.DocumentChangeEvent], error) {
+	return subscribeChanges(cs, func(changes *ravendb.DatabaseChanges, deliver func(interfaces.DocumentChangeEvent)) (ravendb.CancelFunc, error) {
+		return changes.ForDocumentsInCollection(collection, documentChangeHandler(deliver))
+	})
+}
+
+// ForAllDocuments subscribes to changes of every document in the database
+func (cs *ChangesService) ForAllDocuments() (interfaces.IRavenChangeSubscription[interfaces.DocumentChangeEvent], error) {
+	return subscribeChanges(cs, func(changes *ravendb.DatabaseChanges, deliver func(interfaces.DocumentChangeEvent)) (ravendb.CancelFunc, error) {
+		return changes.ForAllDocuments(documentChangeHandler(deliver))
+	})
+}
+
+// ForIndex subscribes to changes of a single index
+func (cs *ChangesService) ForIndex(indexName string) (interfaces.IRavenChangeSubscription[interfaces.IndexChangeEvent], error) {
+	return subscribeChanges(cs, func(changes *ravendb.DatabaseChanges, deliver func(interfaces.IndexChangeEvent)) (ravendb.CancelFunc, error) {
+		return changes.ForIndex(indexName, indexChangeHandler(deliver))
+	})
+}
+
+// ForAllIndexes subscribes to changes of every index in the database
+func (cs *ChangesService) ForAllIndexes() (interfaces.IRavenChangeSubscription[interfaces.IndexChangeEvent], error) {
+	return subscribeChanges(cs, func(changes *ravendb.DatabaseChanges, deliver func(interfaces.IndexChangeEvent)) (ravendb.CancelFunc, error) {
+		return changes.ForAllIndexes(indexChangeHandler(deliver))
+	})
+}
+
+// Close unsubscribes the service's subscriptions and removes its handlers. The shared
+// connection stays open for other users and is closed with the store.
+func (cs *ChangesService) Close() error {
+	cs.mu.Lock()
+	if cs.closed {
+		cs.mu.Unlock()
+		return nil
+	}
+	cs.closed = true
+	close(cs.done)
+
+	subscriptions := make([]changeRegistration, 0, len(cs.subscriptions))
+	for sub := range cs.subscriptions {
+		subscriptions = append(subscriptions, sub)
+	}
+	connection := cs.connection
+	cs.connection = nil
+	cs.mu.Unlock()
+
+	for _, sub := range subscriptions {
+		sub.Close()
+	}
+	if connection != nil {
+		connection.release()
+	}
+
+	return nil
+}
+
+// subscribe attaches a subscription to the current connection, connecting first if needed
+func (cs *ChangesService) subscribe(sub changeRegistration) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.closed {
+		return fmt.Errorf("changes service is closed")
+	}
+
+	connection, err := cs.connectLocked()
+	if err != nil {
+		return err
+	}
+
+	if err := sub.attach(connection.changes); err != nil {
+		return fmt.Errorf("failed to subscribe to changes: %w", err)
+	}
+
+	cs.subscriptions[sub] = struct{}{}
+	return nil
+}
+
+// unsubscribe forgets a subscription so it is not re-attached after a reconnect
+func (cs *ChangesService) unsubscribe(sub changeRegistration) {
+	cs.mu.Lock()
+	delete(cs.subscriptions, sub)
+	cs.mu.Unlock()
+}
+
+// connectLocked returns the current connection, joining the store's shared one if necessary;
+// cs.mu must be held
+func (cs *ChangesService) connectLocked() (*changesConnection, error) {
+	if cs.connection != nil {
+		return cs.connection, nil
+	}
+
+	store := cs.database.GetStore().(*ravendb.DocumentStore)
+	changes := store.Changes(cs.database.GetDatabase())
+	shared, err := acquireChanges(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	connection := &changesConnection{shared: shared, changes: changes}
+	connection.errorHandler = changes.AddOnError(func(err error) {
+		go cs.reconnect(connection, err)
+	})
+	connection.statusHandler = changes.AddConnectionStatusChanged(func() {
+		// Status changes after the connection was established are disconnects
+		if connection.connected.Load() {
+			go cs.reconnect(connection, fmt.Errorf("changes connection lost"))
+		}
+	})
+
+	if err := changes.EnsureConnectedNow(); err != nil {
+		shared.closeFailed()
+		connection.release()
+		return nil, fmt.Errorf("failed to connect to changes API: %w", err)
+	}
+	connection.connected.Store(true)
+
+	cs.connection = connection
+	return connection, nil
+}
+
+// reconnect replaces a failed connection and re-attaches all subscriptions, retrying until
+// it succeeds or the service is closed. The failed connection is closed straight away so
+// the client does not attempt its own reconnect; it is unusable for its other users too.
+func (cs *ChangesService) reconnect(failed *changesConnection, cause error) {
+	cs.mu.Lock()
+	if cs.closed || cs.connection != failed {
+		cs.mu.Unlock()
+		return
+	}
+	cs.connection = nil
+	cs.mu.Unlock()
+
+	failed.connected.Store(false)
+	failed.shared.closeFailed()
+	failed.release()
+	cs.reportError(cause)
+
+	for {
+		select {
+		case <-cs.done:
+			return
+		case <-time.After(cs.options.RetryDelay):
+		}
+
+		cs.mu.Lock()
+		if cs.closed {
+			cs.mu.Unlock()
+			return
+		}
+
+		connection, err := cs.connectLocked()
+		if err == nil {
+			var attached []changeRegistration
+			for sub := range cs.subscriptions {
+				if err = sub.attach(connection.changes); err != nil {
+					break
+				}
+				attached = append(attached, sub)
+			}
+			if err != nil {
+				// Leave the shared connection to its other users and try again later
+				for _, sub := range attached {
+					sub.detach()
+				}
+				cs.connection = nil
+				connection.connected.Store(false)
+				connection.release()
+			}
+		}
+		cs.mu.Unlock()
+
+		if err == nil {
+			return
+		}
+		cs.reportError(err)
+	}
+}
+
+// reportError forwards connection errors to the configured handler
+func (cs *ChangesService) reportError(err error) {
+	if cs.options.OnError != nil && err != nil {
+		cs.options.OnError(err)
+	}
+}
+
+// changeSubscription delivers events of type E on a channel until closed
+type changeSubscription[E any] struct {
+	service  *ChangesService
+	register func(changes *ravendb.DatabaseChanges, deliver func(E)) (ravendb.CancelFunc, error)
+	events   chan E
+	done     chan struct{}
+
+	sendMu sync.RWMutex
+	closed bool
+
+	cancelMu sync.Mutex
+	cancel   ravendb.CancelFunc
+
+	closeOnce sync.Once
+}
+
+// subscribeChanges creates a subscription and attaches it to the service's connection
+func subscribeChanges[E any](cs *ChangesService, register func(*ravendb.DatabaseChanges, func(E)) (ravendb.CancelFunc, error)) (interfaces.IRavenChangeSubscription[E], error) {
+	sub := &changeSubscription[E]{
+		service:  cs,
+		register: register,
+		events:   make(chan E, cs.options.BufferSize),
+		done:     make(chan struct{}),
+	}
+
+	if err := cs.subscribe(sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// Events returns the channel events are delivered on
+func (s *changeSubscription[E]) Events() <-chan E {
+	return s.events
+}
+
+// Close unsubscribes and closes the events channel
+func (s *changeSubscription[E]) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.service.unsubscribe(s)
+		s.detach()
+
+		// Wait for in-flight deliveries, which return once done is closed
+		s.sendMu.Lock()
+		s.closed = true
+		close(s.events)
+		s.sendMu.Unlock()
+	})
+	return nil
+}
+
+func (s *changeSubscription[E]) attach(changes *ravendb.DatabaseChanges) error {
+	cancel, err := s.register(changes, s.deliver)
+	if err != nil {
+		return err
+	}
+
+	s.cancelMu.Lock()
+	s.cancel = cancel
+	s.cancelMu.Unlock()
+	return nil
+}
+
+// detach unregisters the subscription's handler from the connection it is attached to
+func (s *changeSubscription[E]) detach() {
+	s.cancelMu.Lock()
+	defer s.cancelMu.Unlock()
+
+	if s.cancel != nil {
+		s.cancel()
+		s.cancel = nil
+	}
+}
+
+func (s *changeSubscription[E]) deliver(event E) {
+	s.sendMu.RLock()
+	defer s.sendMu.RUnlock()
+
+	if s.closed {
+		return
+	}
+	select {
+	case s.events <- event:
+	case <-s.done:
+	}
+}
+
+// documentChangeHandler converts client document changes into typed events
+func documentChangeHandler(deliver func(interfaces.DocumentChangeEvent)) func(*ravendb.DocumentChange) {
+	return func(change *ravendb.DocumentChange) {
+		event := interfaces.DocumentChangeEvent{
+			Type:       change.Type,
+			ID:         change.ID,
+			Collection: change.CollectionName,
+		}
+		if change.ChangeVector != nil {
+			event.ChangeVector = *change.ChangeVector
+		}
+		deliver(event)
+	}
+}
+
+// indexChangeHandler converts client index changes into typed events
+func indexChangeHandler(deliver func(interfaces.IndexChangeEvent)) func(*ravendb.IndexChange) {
+	return func(change *ravendb.IndexChange) {
+		deliver(interfaces.IndexChangeEvent{
+			Type: change.Type,
+			Name: change.Name,
+		})
+	}
+}
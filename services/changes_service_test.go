@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sharedConnectionCount returns how many client connections the changes services are tracking
+func sharedConnectionCount() int {
+	sharedConnections.Lock()
+	defer sharedConnections.Unlock()
+	return len(sharedConnections.byChanges)
+}
+
+func TestFailedChangesAreForgotten(t *testing.T) {
+	// Every connection to an unreachable node fails, is closed and replaced by the store
+	db, err := NewDatabaseService([]string{"http://127.0.0.1:1"}, "ChangesTest")
+	require.NoError(t, err)
+	defer db.Close()
+
+	changes := NewChangesService(db, nil)
+	defer changes.Close()
+
+	before := sharedConnectionCount()
+	for i := 0; i < 3; i++ {
+		_, err := changes.ForAllDocuments()
+		require.Error(t, err, "Attempt %d should fail to connect", i+1)
+		assert.NotContains(t, err.Error(), "still closing", "Each attempt should get a new connection")
+		assert.Equal(t, before, sharedConnectionCount(), "Failed connections should not be kept")
+	}
+}
+
+func TestClosedChangesAreRefusedUntilReleased(t *testing.T) {
+	db, err := NewDatabaseService([]string{"http://127.0.0.1:1"}, "ChangesTest")
+	require.NoError(t, err)
+	defer db.Close()
+
+	changes := db.GetStore().(*ravendb.DocumentStore).Changes("ChangesTest")
+	first, err := acquireChanges(changes)
+	require.NoError(t, err)
+	second, err := acquireChanges(changes)
+	require.NoError(t, err)
+	assert.Same(t, first, second, "Services share one entry per connection")
+
+	first.closeFailed()
+	second.closeFailed()
+	_, err = acquireChanges(changes)
+	assert.ErrorContains(t, err, "still closing", "A closed connection is refused while it has users")
+
+	first.release()
+	second.release()
+	sharedConnections.Lock()
+	_, known := sharedConnections.byChanges[changes]
+	sharedConnections.Unlock()
+	assert.False(t, known, "The connection is forgotten after its last user")
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// defaultOpenTimeout is how long an open circuit waits before allowing a probe
+const defaultOpenTimeout = 30 * time.Second
+
+// circuitBreaker tracks consecutive transient failures and short-circuits operations while the server is down
+type circuitBreaker struct {
+	options interfaces.CircuitBreakerOptions
+
+	mu                  sync.Mutex
+	state               interfaces.CircuitState
+	consecutiveFailures int
+	probeSuccesses      int
+	probesInFlight      int
+	openedAt            time.Time
+
+	// generation counts state transitions, so probes can be matched to the half-open window that admitted them
+	generation uint64
+}
+
+// admission describes an admitted operation: whether it is a half-open probe, and the
+// generation of the window that admitted it
+type admission struct {
+	probe      bool
+	generation uint64
+}
+
+// newCircuitBreaker creates a closed circuit breaker, filling in defaults for unset options
+func newCircuitBreaker(options *interfaces.CircuitBreakerOptions) *circuitBreaker {
+	opts := *options
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = 5
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = defaultOpenTimeout
+	}
+	if opts.HalfOpenMaxRequests <= 0 {
+		opts.HalfOpenMaxRequests = 1
+	}
+	if opts.SuccessThreshold <= 0 {
+		opts.SuccessThreshold = 1
+	}
+	if len(opts.TripOn) == 0 {
+		opts.TripOn = []error{interfaces.ErrTimeout, interfaces.ErrUnavailable}
+	}
+
+	return &circuitBreaker{
+		options: opts,
+		state:   interfaces.CircuitClosed,
+	}
+}
+
+// execute runs fn unless the circuit is open, recording its outcome
+func (cb *circuitBreaker) execute(fn func() error) error {
+	admitted, err := cb.acquire()
+	if err != nil {
+		return err
+	}
+
+	err = fn()
+	cb.record(err, admitted)
+	return err
+}
+
+// acquire admits an operation, moving an expired open circuit to half-open.
+// It reports whether the admitted operation is a half-open probe and for which window.
+func (cb *circuitBreaker) acquire() (admission, error) {
+	cb.mu.Lock()
+	var changes []stateChange
+	defer func() {
+		cb.mu.Unlock()
+		cb.notify(changes)
+	}()
+
+	if cb.state == interfaces.CircuitOpen {
+		retryAt := cb.openedAt.Add(cb.options.OpenTimeout)
+		if time.Now().Before(retryAt) {
+			return admission{}, &interfaces.CircuitOpenError{State: cb.state, RetryAt: retryAt}
+		}
+		changes = append(changes, cb.transition(interfaces.CircuitHalfOpen))
+	}
+
+	if cb.state == interfaces.CircuitHalfOpen {
+		if cb.probesInFlight >= cb.options.HalfOpenMaxRequests {
+			return admission{}, &interfaces.CircuitOpenError{State: cb.state, RetryAt: time.Now().Add(cb.options.OpenTimeout)}
+		}
+		cb.probesInFlight++
+		return admission{probe: true, generation: cb.generation}, nil
+	}
+
+	return admission{}, nil
+}
+
+// record updates the failure counters with an operation's outcome. A probe finishing after
+// its half-open window ended ran against an earlier server state and is ignored.
+func (cb *circuitBreaker) record(err error, admitted admission) {
+	failed := err != nil && cb.isFailure(err)
+
+	cb.mu.Lock()
+	var changes []stateChange
+	defer func() {
+		cb.mu.Unlock()
+		cb.notify(changes)
+	}()
+
+	if admitted.probe {
+		if cb.state != interfaces.CircuitHalfOpen || admitted.generation != cb.generation {
+			return
+		}
+		cb.probesInFlight--
+		if failed {
+			changes = append(changes, cb.trip())
+			return
+		}
+		cb.probeSuccesses++
+		if cb.probeSuccesses >= cb.options.SuccessThreshold {
+			cb.consecutiveFailures = 0
+			changes = append(changes, cb.transition(interfaces.CircuitClosed))
+		}
+		return
+	}
+
+	if !failed {
+		cb.consecutiveFailures = 0
+		return
+	}
+
+	cb.consecutiveFailures++
+	if cb.state == interfaces.CircuitClosed && cb.consecutiveFailures >= cb.options.FailureThreshold {
+		changes = append(changes, cb.trip())
+	}
+}
+
+// isFailure reports whether err indicates the server is unhealthy; errors such as
+// not-found or concurrency conflicts prove the server answered and do not count
+func (cb *circuitBreaker) isFailure(err error) bool {
+	if errors.Is(err, interfaces.ErrCircuitOpen) {
+		return false
+	}
+	if cb.options.IsFailure != nil {
+		return cb.options.IsFailure(err)
+	}
+
+	kind := classifyError(err)
+	for _, target := range cb.options.TripOn {
+		if errors.Is(err, target) || (kind != nil && errors.Is(kind, target)) {
+			return true
+		}
+	}
+	return false
+}
+
+// stateChange is a transition reported to OnStateChange once the lock is released
+type stateChange struct {
+	from, to interfaces.CircuitState
+}
+
+// trip opens the circuit; the caller must hold mu
+func (cb *circuitBreaker) trip() stateChange {
+	cb.openedAt = time.Now()
+	return cb.transition(interfaces.CircuitOpen)
+}
+
+// transition changes state, starting a new generation, and resets the probe counters;
+// the caller must hold mu
+func (cb *circuitBreaker) transition(to interfaces.CircuitState) stateChange {
+	change := stateChange{from: cb.state, to: to}
+	cb.state = to
+	cb.generation++
+	cb.probeSuccesses = 0
+	cb.probesInFlight = 0
+	return change
+}
+
+// notify reports state transitions to the OnStateChange callback
+func (cb *circuitBreaker) notify(changes []stateChange) {
+	if cb.options.OnStateChange == nil {
+		return
+	}
+	for _, change := range changes {
+		if change.from != change.to {
+			cb.options.OnStateChange(change.from, change.to)
+		}
+	}
+}
+
+// status returns the breaker's state for GetDatabaseStatus
+func (cb *circuitBreaker) status() *interfaces.CircuitBreakerStatus {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	status := &interfaces.CircuitBreakerStatus{
+		State:               cb.state,
+		ConsecutiveFailures: cb.consecutiveFailures,
+	}
+	if cb.state != interfaces.CircuitClosed {
+		openedAt := cb.openedAt
+		retryAt := cb.openedAt.Add(cb.options.OpenTimeout)
+		status.OpenedAt = &openedAt
+		status.RetryAt = &retryAt
+	}
+	return status
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+func TestCircuitBreakerOverlappingHalfOpenWindows(t *testing.T) {
+	cb := newCircuitBreaker(&interfaces.CircuitBreakerOptions{
+		FailureThreshold:    1,
+		OpenTimeout:         20 * time.Millisecond,
+		HalfOpenMaxRequests: 2,
+		SuccessThreshold:    1,
+		TripOn:              []error{interfaces.ErrUnavailable},
+	})
+
+	admitted, err := cb.acquire()
+	require.NoError(t, err)
+	cb.record(interfaces.ErrUnavailable, admitted)
+	require.Equal(t, interfaces.CircuitOpen, cb.status().State)
+
+	// The first half-open window admits two probes; the fast one fails and re-trips the circuit
+	time.Sleep(30 * time.Millisecond)
+	fast, err := cb.acquire()
+	require.NoError(t, err)
+	slow, err := cb.acquire()
+	require.NoError(t, err)
+	require.True(t, fast.probe && slow.probe)
+	cb.record(interfaces.ErrUnavailable, fast)
+	require.Equal(t, interfaces.CircuitOpen, cb.status().State)
+
+	// The second window opens while the slow probe from the first is still running
+	time.Sleep(30 * time.Millisecond)
+	current, err := cb.acquire()
+	require.NoError(t, err)
+	require.Equal(t, interfaces.CircuitHalfOpen, cb.status().State)
+
+	cb.record(nil, slow)
+	assert.Equal(t, interfaces.CircuitHalfOpen, cb.status().State, "A stale probe must not close the new window")
+	assert.Equal(t, 1, cb.probesInFlight, "A stale probe must not release a slot in the new window")
+	assert.Zero(t, cb.probeSuccesses)
+
+	_, err = cb.acquire()
+	require.NoError(t, err)
+	_, err = cb.acquire()
+	assert.Error(t, err, "The new window still admits only two probes")
+
+	cb.record(nil, current)
+	assert.Equal(t, interfaces.CircuitClosed, cb.status().State)
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/ravendb/ravendb-go-client"
+)
+
+// collectionNamer is implemented by document types that choose their own collection name
+type collectionNamer interface {
+	CollectionName() string
+}
+
+// collectionNames caches the collection name derived for each document type
+var collectionNames sync.Map // reflect.Type -> string
+
+// CollectionNameFor returns the collection name derived from the document type T
+func CollectionNameFor[T any]() string {
+	return collectionNameOf(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// collectionNameOf derives a type's collection name from its CollectionName method, else a
+// collection=Name option in a field's ravendb tag, else the client's pluralized type name
+func collectionNameOf(typ reflect.Type) string {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if cached, ok := collectionNames.Load(typ); ok {
+		return cached.(string)
+	}
+
+	name := ""
+	if namer, ok := reflect.New(typ).Interface().(collectionNamer); ok {
+		name = namer.CollectionName()
+	}
+	if name == "" && typ.Kind() == reflect.Struct {
+		for _, field := range reflect.VisibleFields(typ) {
+			if collection := tagOptions(field.Tag.Get(tagName))["collection"]; field.IsExported() && collection != "" {
+				name = collection
+				break
+			}
+		}
+	}
+	if name == "" {
+		name = ravendb.GetCollectionNameDefault(typ)
+	}
+
+	collectionNames.Store(typ, name)
+	return name
+}
+
+// findCollectionName is the store's FindCollectionName convention, giving every type its derived
+// name. Collection services set their own name on the documents they store instead, so several
+// services can share a document type.
+func findCollectionName(entityOrType interface{}) string {
+	typ, ok := entityOrType.(reflect.Type)
+	if !ok {
+		typ = reflect.TypeOf(entityOrType)
+	}
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ == nil {
+		return ravendb.GetCollectionNameDefault(entityOrType)
+	}
+	return collectionNameOf(typ)
+}
@@ -12,269 +12,467 @@ import (
 type CollectionService[T any] struct {
 	database   interfaces.IRavenDBService
 	collection string
+	options    interfaces.CollectionOptions
 }
 
-// NewCollectionService creates a new collection service for a specific document type
+// NewCollectionService creates a new collection service for a specific document type. An empty
+// collection name is derived from the type, see CollectionNameFor.
 func NewCollectionService[T any](database interfaces.IRavenDBService, collection string) interfaces.IRavenCollectionService[T] {
-	return &CollectionService[T]{
+	return NewCollectionServiceWithOptions[T](database, collection, nil)
+}
+
+// NewCollectionServiceWithOptions creates a new collection service with collection-level defaults
+func NewCollectionServiceWithOptions[T any](database interfaces.IRavenDBService, collection string, options *interfaces.CollectionOptions) interfaces.IRavenCollectionService[T] {
+	if collection == "" {
+		collection = CollectionNameFor[T]()
+	}
+	cs := &CollectionService[T]{
 		database:   database,
 		collection: collection,
 	}
+	if options != nil {
+		cs.options = *options
+	}
+	return cs
 }
 
 // CRUD Operations
 
-// Store stores a document with the specified ID
-func (cs *CollectionService[T]) Store(id string, document T) error {
-	store := cs.database.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(cs.database.GetDatabase())
-	if err != nil {
-		return fmt.Errorf("failed to open session: %w", err)
-	}
-	defer session.Close()
+// Store stores a document with the specified ID. When id is empty the document's ID field is used, and
+// when that is empty too the collection's ID strategy. The assigned ID is written back into the
+// document's ID field and returned with the new change vector. Documents failing validation are
+// rejected with a *interfaces.ValidationError before the server is contacted.
+func (cs *CollectionService[T]) Store(id string, document *T) (*interfaces.StoreResult, error) {
+	return cs.StoreWithOptions(id, document, nil)
+}
 
-	// Use the proper Store API from the documentation - RavenDB expects a pointer
-	if id != "" {
-		err = session.StoreWithID(&document, id)
-	} else {
-		err = session.Store(&document)
+// StoreWithOptions stores a document like Store with per-document options
+func (cs *CollectionService[T]) StoreWithOptions(id string, document *T, options *interfaces.StoreOptions) (*interfaces.StoreResult, error) {
+	if document == nil {
+		return nil, newOperationError("failed to store document", id, cs.collection, "", errNilDocument)
 	}
-
-	if err != nil {
-		return fmt.Errorf("failed to store document: %w", err)
+	if err := cs.validate(id, document); err != nil {
+		return nil, err
 	}
-
-	return session.SaveChanges()
-}
-
-// StoreMultiple stores multiple documents in a single transaction
-func (cs *CollectionService[T]) StoreMultiple(documents map[string]T) error {
-	store := cs.database.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(cs.database.GetDatabase())
+	id, err := cs.resolveID(id, document)
 	if err != nil {
-		return fmt.Errorf("failed to open session: %w", err)
+		return nil, newOperationError("failed to generate document ID", "", cs.collection, "", err)
 	}
-	defer session.Close()
 
-	for id, document := range documents {
-		// Need to pass pointer to RavenDB
-		doc := document // Create a copy to take address of
+	return runOperationResult(cs.database, isFinalID(id), func() (*interfaces.StoreResult, error) {
+		store := cs.database.GetStore().(*ravendb.DocumentStore)
+		session, err := store.OpenSession(cs.database.GetDatabase())
+		if err != nil {
+			return nil, newOperationError("failed to open session", "", cs.collection, "", err)
+		}
+		defer session.Close()
+
 		if id != "" {
-			err = session.StoreWithID(&doc, id)
+			err = session.StoreWithID(document, id)
 		} else {
-			err = session.Store(&doc)
+			err = session.Store(document)
 		}
+
 		if err != nil {
-			return fmt.Errorf("failed to store document with ID %s: %w", id, err)
+			return nil, newOperationError("failed to store document", id, cs.collection, "", err)
 		}
+
+		if err := applyStoreMetadata(session, document, cs.collection, options, cs.options.DefaultTTL); err != nil {
+			return nil, err
+		}
+
+		if err := session.SaveChanges(); err != nil {
+			return nil, newOperationError("failed to save document", id, cs.collection, "", err)
+		}
+		return storedResult(session, document), nil
+	})
+}
+
+// StoreMultiple stores multiple documents in a single transaction. The results are keyed like the
+// input, so the document stored under the empty key reports its assigned ID. Nothing is stored if
+// any document fails validation.
+func (cs *CollectionService[T]) StoreMultiple(documents map[string]*T) (map[string]*interfaces.StoreResult, error) {
+	// Keys stay as given for the results; the empty key is stored under the document's own or a generated ID
+	for key, document := range documents {
+		if document == nil {
+			return nil, newOperationError("failed to store document", key, cs.collection, "", errNilDocument)
+		}
+	}
+	if err := cs.validateAll(documents); err != nil {
+		return nil, err
 	}
 
-	return session.SaveChanges()
+	ids := make(map[string]string, len(documents))
+	idempotent := true
+	for key, document := range documents {
+		id, err := cs.resolveID(key, document)
+		if err != nil {
+			return nil, newOperationError("failed to generate document ID", "", cs.collection, "", err)
+		}
+		ids[key] = id
+		idempotent = idempotent && isFinalID(id)
+	}
+
+	return runOperationResult(cs.database, idempotent, func() (map[string]*interfaces.StoreResult, error) {
+		store := cs.database.GetStore().(*ravendb.DocumentStore)
+		session, err := store.OpenSession(cs.database.GetDatabase())
+		if err != nil {
+			return nil, newOperationError("failed to open session", "", cs.collection, "", err)
+		}
+		defer session.Close()
+
+		for key, document := range documents {
+			id := ids[key]
+			if id != "" {
+				err = session.StoreWithID(document, id)
+			} else {
+				err = session.Store(document)
+			}
+			if err != nil {
+				return nil, newOperationError("failed to store document", id, cs.collection, "", err)
+			}
+			if err := applyStoreMetadata(session, document, cs.collection, nil, cs.options.DefaultTTL); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := session.SaveChanges(); err != nil {
+			return nil, newOperationError("failed to save documents", "", cs.collection, "", err)
+		}
+
+		results := make(map[string]*interfaces.StoreResult, len(documents))
+		for key, document := range documents {
+			results[key] = storedResult(session, document)
+		}
+		return results, nil
+	})
 }
 
-// LoadByID loads a document by ID
-func (cs *CollectionService[T]) LoadByID(id string) (*T, error) {
-	store := cs.database.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(cs.database.GetDatabase())
+// Create stores a new document under id, else its ID field, else one from the collection's ID
+// strategy, returning an error matching interfaces.ErrDocumentAlreadyExists if the ID is taken.
+// The check is made by the server as part of the write.
+func (cs *CollectionService[T]) Create(id string, document *T) (*interfaces.StoreResult, error) {
+	if document == nil {
+		return nil, newOperationError("failed to create document", id, cs.collection, "", errNilDocument)
+	}
+	if err := cs.validate(id, document); err != nil {
+		return nil, err
+	}
+	id, err := cs.resolveRawID(id, document)
+	if err != nil {
+		return nil, newOperationError("failed to generate document ID", "", cs.collection, "", err)
+	}
+	data, err := entityDocument(document, cs.collection, nil, cs.options.DefaultTTL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open session: %w", err)
+		return nil, newOperationError("failed to create document", id, cs.collection, "", err)
 	}
-	defer session.Close()
 
-	var result *T
-	err = session.Load(&result, id)
+	mustNotExist := ""
+	result, err := putDocument(cs.database, id, data, &mustNotExist)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load document: %w", err)
+		if classifyError(err) == interfaces.ErrConcurrencyConflict {
+			return nil, &interfaces.OperationError{
+				Op:         "failed to create document",
+				ID:         id,
+				Collection: cs.collection,
+				Kind:       interfaces.ErrDocumentAlreadyExists,
+				Err:        err,
+			}
+		}
+		return nil, newOperationError("failed to create document", id, cs.collection, "", err)
 	}
+	return putResult(document, result), nil
+}
 
-	// If document doesn't exist, result will be nil
-	if result == nil {
-		return nil, nil
+// Replace overwrites an existing document, identified by id or its ID field, returning an error
+// matching interfaces.ErrNotFound if it does not exist. The write is conditional on the change vector
+// read beforehand, so a concurrent change or delete fails with interfaces.ErrConcurrencyConflict
+// instead of being overwritten.
+func (cs *CollectionService[T]) Replace(id string, document *T) (*interfaces.StoreResult, error) {
+	if document == nil {
+		return nil, newOperationError("failed to replace document", id, cs.collection, "", errNilDocument)
+	}
+	if id == "" {
+		id = documentIDOf(document)
+	}
+	if err := cs.validate(id, document); err != nil {
+		return nil, err
+	}
+	data, err := entityDocument(document, cs.collection, nil, cs.options.DefaultTTL)
+	if err != nil {
+		return nil, newOperationError("failed to replace document", id, cs.collection, "", err)
 	}
 
-	return result, nil
-}
+	changeVector, err := headDocument(cs.database, id)
+	if err != nil {
+		return nil, newOperationError("failed to replace document", id, cs.collection, "", err)
+	}
+	if changeVector == "" {
+		return nil, newNotFoundError("failed to replace document", id, cs.collection)
+	}
 
-// LoadMultipleByIDs loads multiple documents by their IDs
-func (cs *CollectionService[T]) LoadMultipleByIDs(ids []string) ([]T, error) {
-	store := cs.database.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(cs.database.GetDatabase())
+	result, err := putDocument(cs.database, id, data, &changeVector)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open session: %w", err)
+		return nil, newOperationError("failed to replace document", id, cs.collection, "", err)
 	}
-	defer session.Close()
+	return putResult(document, result), nil
+}
+
+// Upsert stores a document whether or not it exists
+func (cs *CollectionService[T]) Upsert(id string, document *T) (*interfaces.StoreResult, error) {
+	return cs.Store(id, document)
+}
+
+// LoadByID loads a document by ID, returning an error matching interfaces.ErrNotFound if it does not exist
+func (cs *CollectionService[T]) LoadByID(id string) (*T, error) {
+	return runOperationResult(cs.database, true, func() (*T, error) {
+		store := cs.database.GetStore().(*ravendb.DocumentStore)
+		session, err := store.OpenSession(cs.database.GetDatabase())
+		if err != nil {
+			return nil, newOperationError("failed to open session", "", cs.collection, "", err)
+		}
+		defer session.Close()
 
-	var results []T
-	for _, id := range ids {
-		var doc *T
-		err = session.Load(&doc, id)
+		var result *T
+		err = session.Load(&result, id)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load document %s: %w", id, err)
+			return nil, newOperationError("failed to load document", id, cs.collection, "", err)
 		}
-		// Check if document exists
-		if doc != nil {
-			results = append(results, *doc)
+
+		// If document doesn't exist, result will be nil
+		if result == nil {
+			return nil, newNotFoundError("failed to load document", id, cs.collection)
 		}
-	}
 
-	return results, nil
+		setDocumentID(result, session.Advanced().GetDocumentID(result))
+		return result, nil
+	})
 }
 
-// Update updates an existing document
-func (cs *CollectionService[T]) Update(id string, document T) error {
-	store := cs.database.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(cs.database.GetDatabase())
+// LoadMultipleByIDs loads multiple documents by their IDs in a single request, in the requested order.
+// Missing IDs are skipped.
+func (cs *CollectionService[T]) LoadMultipleByIDs(ids []string) ([]T, error) {
+	result, err := cs.LoadMultipleByIDsWithMissing(ids)
 	if err != nil {
-		return fmt.Errorf("failed to open session: %w", err)
+		return nil, err
 	}
-	defer session.Close()
+	return result.Results, nil
+}
 
-	// Store the updated document - RavenDB expects a pointer
-	err = session.StoreWithID(&document, id)
+// LoadMultipleByIDsWithMissing loads documents like LoadMultipleByIDs and reports the IDs that do not exist
+func (cs *CollectionService[T]) LoadMultipleByIDsWithMissing(ids []string) (*interfaces.LoadResult[T], error) {
+	documents, err := loadDocuments(cs.database, ids, false)
 	if err != nil {
-		return fmt.Errorf("failed to store updated document: %w", err)
+		return nil, newOperationError("failed to load documents", "", cs.collection, "", err)
+	}
+
+	result := &interfaces.LoadResult[T]{Results: make([]T, 0, len(ids))}
+	for i, document := range documents {
+		if document == nil {
+			result.Missing = append(result.Missing, ids[i])
+			continue
+		}
+		var value T
+		if err := remarshal(document, &value); err != nil {
+			return nil, newOperationError("failed to decode document", ids[i], cs.collection, "", err)
+		}
+		setDocumentID(&value, documentID(document))
+		result.Results = append(result.Results, value)
 	}
 
-	return session.SaveChanges()
+	return result, nil
 }
 
-// Delete removes a document by ID
-func (cs *CollectionService[T]) Delete(id string) error {
-	store := cs.database.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(cs.database.GetDatabase())
+// LoadWithMetadata loads a document together with its typed metadata, returning an error matching
+// interfaces.ErrNotFound if it does not exist
+func (cs *CollectionService[T]) LoadWithMetadata(id string) (*interfaces.Document[T], error) {
+	result, err := cs.LoadMultipleWithMetadata([]string{id})
 	if err != nil {
-		return fmt.Errorf("failed to open session: %w", err)
+		return nil, err
 	}
-	defer session.Close()
+	if len(result.Results) == 0 {
+		return nil, newNotFoundError("failed to load document", id, cs.collection)
+	}
+	return &result.Results[0], nil
+}
 
-	// Load document first, then delete - same pattern as database service
-	var document *T
-	err = session.Load(&document, id)
+// LoadMultipleWithMetadata loads documents with their metadata in a single request, in the requested
+// order, and reports the IDs that do not exist
+func (cs *CollectionService[T]) LoadMultipleWithMetadata(ids []string) (*interfaces.LoadResult[interfaces.Document[T]], error) {
+	documents, err := loadDocuments(cs.database, ids, false)
 	if err != nil {
-		return fmt.Errorf("failed to load document for deletion: %w", err)
+		return nil, newOperationError("failed to load documents", "", cs.collection, "", err)
 	}
 
-	if document == nil {
-		return fmt.Errorf("document with ID %s not found", id)
+	result := &interfaces.LoadResult[interfaces.Document[T]]{Results: make([]interfaces.Document[T], 0, len(ids))}
+	for i, document := range documents {
+		if document == nil {
+			result.Missing = append(result.Missing, ids[i])
+			continue
+		}
+		loaded := interfaces.Document[T]{Metadata: parseMetadata(document)}
+		if err := remarshal(document, &loaded.Document); err != nil {
+			return nil, newOperationError("failed to decode document", ids[i], cs.collection, "", err)
+		}
+		setDocumentID(&loaded.Document, loaded.Metadata.ID)
+		result.Results = append(result.Results, loaded)
 	}
 
-	session.Delete(document)
-	return session.SaveChanges()
+	return result, nil
 }
 
-// DeleteMultiple removes multiple documents by their IDs
-func (cs *CollectionService[T]) DeleteMultiple(ids []string) error {
-	store := cs.database.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(cs.database.GetDatabase())
-	if err != nil {
-		return fmt.Errorf("failed to open session: %w", err)
+// Update updates an existing document, keeping its metadata, and takes the ID from the document's
+// ID field when id is empty
+func (cs *CollectionService[T]) Update(id string, document *T) error {
+	if document == nil {
+		return newOperationError("failed to update document", id, cs.collection, "", errNilDocument)
 	}
-	defer session.Close()
-
-	// Load each document first, then delete - same pattern as database service
-	for _, id := range ids {
-		var document *T
-		err = session.Load(&document, id)
+	if id == "" {
+		id = documentIDOf(document)
+	}
+	if err := cs.validate(id, document); err != nil {
+		return err
+	}
+	return runOperation(cs.database, true, func() error {
+		store := cs.database.GetStore().(*ravendb.DocumentStore)
+		session, err := store.OpenSession(cs.database.GetDatabase())
 		if err != nil {
-			return fmt.Errorf("failed to load document %s for deletion: %w", id, err)
+			return newOperationError("failed to open session", "", cs.collection, "", err)
 		}
+		defer session.Close()
 
-		if document != nil {
-			session.Delete(document)
+		// Overwrite the tracked document so its metadata is kept; a missing document is stored anew
+		var existing *T
+		if err := session.Load(&existing, id); err != nil {
+			return newOperationError("failed to load document", id, cs.collection, "", err)
 		}
-		// Skip if document doesn't exist instead of failing
-	}
+		if existing != nil {
+			*existing = *document
+		} else {
+			if err := session.StoreWithID(document, id); err != nil {
+				return newOperationError("failed to store updated document", id, cs.collection, "", err)
+			}
+			if err := applyStoreMetadata(session, document, cs.collection, nil, cs.options.DefaultTTL); err != nil {
+				return err
+			}
+		}
+
+		if err := session.SaveChanges(); err != nil {
+			return newOperationError("failed to save updated document", id, cs.collection, "", err)
+		}
+		return nil
+	})
+}
+
+// Delete removes a document by ID without loading it, returning an error matching interfaces.ErrNotFound if it does not exist
+func (cs *CollectionService[T]) Delete(id string) error {
+	return cs.DeleteWithOptions(id, nil)
+}
+
+// DeleteWithOptions removes a document by ID, optionally only if its change vector matches
+func (cs *CollectionService[T]) DeleteWithOptions(id string, options *interfaces.DeleteOptions) error {
+	return deleteOne(cs.database, id, cs.collection, options)
+}
+
+// DeleteMultiple removes multiple documents by their IDs, skipping IDs that do not exist
+func (cs *CollectionService[T]) DeleteMultiple(ids []string) error {
+	_, err := cs.DeleteMultipleWithOptions(ids, nil)
+	return err
+}
 
-	return session.SaveChanges()
+// DeleteMultipleWithOptions removes documents in one transaction and returns the IDs that existed
+func (cs *CollectionService[T]) DeleteMultipleWithOptions(ids []string, options *interfaces.DeleteOptions) ([]string, error) {
+	return deleteMany(cs.database, ids, cs.collection, options)
 }
 
 // Query Operations
 
 // Query executes a generic query with options
 func (cs *CollectionService[T]) Query(options *interfaces.QueryOptions) (*interfaces.GenericQueryResult[T], error) {
-	store := cs.database.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(cs.database.GetDatabase())
-	if err != nil {
-		return nil, fmt.Errorf("failed to open session: %w", err)
-	}
-	defer session.Close()
+	return runOperationResult(cs.database, true, func() (*interfaces.GenericQueryResult[T], error) {
+		store := cs.database.GetStore().(*ravendb.DocumentStore)
+		session, err := store.OpenSession(cs.database.GetDatabase())
+		if err != nil {
+			return nil, newOperationError("failed to open session", "", cs.collection, "", err)
+		}
+		defer session.Close()
 
-	// Set default values
-	if options == nil {
-		options = &interfaces.QueryOptions{}
-	}
-	if options.Take <= 0 {
-		options.Take = 25
-	}
-	if options.Take > 1024 {
-		options.Take = 1024
-	}
+		// Set default values
+		if options == nil {
+			options = &interfaces.QueryOptions{}
+		}
+		if options.Take <= 0 {
+			options.Take = 25
+		}
+		if options.Take > 1024 {
+			options.Take = 1024
+		}
 
-	// Build RQL query dynamically
-	var rqlQuery strings.Builder
-	// TODO: Fix collection metadata filtering - RavenDB Go client collection assignment needs investigation
-	// For now, query all documents to ensure tests pass
-	rqlQuery.WriteString("from @all_docs")
+		// Build RQL query dynamically
+		var rqlQuery strings.Builder
+		rqlQuery.WriteString("from " + collectionSource(cs.collection))
 
-	// Add WHERE clause if specified
-	if options.WhereClause != "" {
-		rqlQuery.WriteString(fmt.Sprintf(" WHERE (%s)", options.WhereClause))
-	}
+		// Add WHERE clause if specified
+		if options.WhereClause != "" {
+			rqlQuery.WriteString(fmt.Sprintf(" WHERE (%s)", options.WhereClause))
+		}
 
-	// Add ORDER BY if specified
-	if options.OrderBy != "" {
-		if options.OrderDesc {
-			rqlQuery.WriteString(fmt.Sprintf(" ORDER BY %s DESC", options.OrderBy))
-		} else {
-			rqlQuery.WriteString(fmt.Sprintf(" ORDER BY %s", options.OrderBy))
+		// Add ORDER BY if specified
+		if options.OrderBy != "" {
+			if options.OrderDesc {
+				rqlQuery.WriteString(fmt.Sprintf(" ORDER BY %s DESC", options.OrderBy))
+			} else {
+				rqlQuery.WriteString(fmt.Sprintf(" ORDER BY %s", options.OrderBy))
+			}
 		}
-	}
 
-	// Add LIMIT (skip, take) for pagination
-	if options.Skip > 0 || options.Take > 0 {
-		skip := options.Skip
-		take := options.Take
-		if take <= 0 {
-			take = 25
+		// Add LIMIT (skip, take) for pagination
+		if options.Skip > 0 || options.Take > 0 {
+			skip := options.Skip
+			take := options.Take
+			if take <= 0 {
+				take = 25
+			}
+			rqlQuery.WriteString(fmt.Sprintf(" LIMIT %d, %d", skip, take))
 		}
-		rqlQuery.WriteString(fmt.Sprintf(" LIMIT %d, %d", skip, take))
-	}
 
-	// Execute the raw query
-	queryStr := rqlQuery.String()
-	query := session.Advanced().RawQuery(queryStr)
+		// Execute the raw query
+		queryStr := rqlQuery.String()
+		query := session.Advanced().RawQuery(queryStr)
 
-	// Set parameters if provided
-	if options.Parameters != nil {
-		for key, value := range options.Parameters {
-			query = query.AddParameter(key, value)
+		// Set parameters if provided
+		if options.Parameters != nil {
+			for key, value := range options.Parameters {
+				query = query.AddParameter(key, value)
+			}
 		}
-	}
-
-	var results []*T
-	err = query.GetResults(&results)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
-	}
 
-	// Convert pointers to values
-	finalResults := make([]T, len(results))
-	for i, res := range results {
-		if res != nil {
-			finalResults[i] = *res
+		var results []*T
+		err = query.GetResults(&results)
+		if err != nil {
+			return nil, newOperationError("failed to execute query", "", cs.collection, queryStr, err)
 		}
-	}
 
-	totalCount := len(finalResults)
-	hasMore := options.Take > 0 && totalCount == options.Take
+		// Convert pointers to values, filling in each document's ID field
+		finalResults := make([]T, len(results))
+		for i, res := range results {
+			if res != nil {
+				setDocumentID(res, session.Advanced().GetDocumentID(res))
+				finalResults[i] = *res
+			}
+		}
 
-	return &interfaces.GenericQueryResult[T]{
-		Results:    finalResults,
-		TotalCount: totalCount,
-		Skip:       options.Skip,
-		Take:       options.Take,
-		HasMore:    hasMore,
-	}, nil
+		totalCount := len(finalResults)
+		hasMore := options.Take > 0 && totalCount == options.Take
+
+		return &interfaces.GenericQueryResult[T]{
+			Results:    finalResults,
+			TotalCount: totalCount,
+			Skip:       options.Skip,
+			Take:       options.Take,
+			HasMore:    hasMore,
+		}, nil
+	})
 }
 
 // QueryAll queries all documents of type T
@@ -347,30 +545,36 @@ func (cs *CollectionService[T]) Search(searchTerm string, searchFields []string,
 
 // Utility Methods
 
-// Exists checks if a document with the given ID exists
+// Exists checks if a document with the given ID exists using a HEAD request
 func (cs *CollectionService[T]) Exists(id string) (bool, error) {
-	store := cs.database.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(cs.database.GetDatabase())
+	changeVector, err := cs.ExistsWithChangeVector(id)
+	return changeVector != "", err
+}
+
+// ExistsWithChangeVector returns the document's current change vector, or an empty string if it does not exist
+func (cs *CollectionService[T]) ExistsWithChangeVector(id string) (string, error) {
+	changeVector, err := headDocument(cs.database, id)
 	if err != nil {
-		return false, fmt.Errorf("failed to open session: %w", err)
+		return "", newOperationError("failed to check document existence", id, cs.collection, "", err)
 	}
-	defer session.Close()
+	return changeVector, nil
+}
 
-	var document *T
-	err = session.Load(&document, id)
+// ExistsMany checks several documents in one metadata-only request, returning the change vectors of those that exist
+func (cs *CollectionService[T]) ExistsMany(ids []string) (map[string]string, error) {
+	changeVectors, err := documentsExist(cs.database, ids)
 	if err != nil {
-		return false, fmt.Errorf("failed to check document existence: %w", err)
+		return nil, newOperationError("failed to check document existence", "", cs.collection, "", err)
 	}
-
-	// Document exists if the result is not nil
-	return document != nil, nil
+	return changeVectors, nil
 }
 
 // Count returns the total number of documents in this collection
 func (cs *CollectionService[T]) Count() (int, error) {
-	result, err := cs.QueryAll()
-	if err != nil {
-		return 0, fmt.Errorf("failed to count documents: %w", err)
-	}
-	return result.TotalCount, nil
+	return countDocuments(cs.database, cs.collection, nil)
+}
+
+// CountWithOptions returns the number of documents in this collection matching the options' WhereClause
+func (cs *CollectionService[T]) CountWithOptions(options *interfaces.QueryOptions) (int, error) {
+	return countDocuments(cs.database, cs.collection, options)
 }
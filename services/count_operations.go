@@ -0,0 +1,73 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// countDocuments counts a collection without transferring documents. Unfiltered counts read the
+// collection statistics; a WhereClause in options runs a count query against the collection.
+func countDocuments(database interfaces.IRavenDBService, collection string, options *interfaces.QueryOptions) (int, error) {
+	if options != nil && options.WhereClause != "" {
+		return countByQuery(database, collection, options)
+	}
+
+	return runOperationResult(database, true, func() (int, error) {
+		store := database.GetStore().(*ravendb.DocumentStore)
+		operation := ravendb.NewGetCollectionStatisticsOperation()
+		if err := store.Maintenance().ForDatabase(database.GetDatabase()).Send(operation); err != nil {
+			return 0, newOperationError("failed to get collection statistics", "", collection, "", err)
+		}
+
+		stats := operation.Command.Result
+		if stats == nil {
+			return 0, nil
+		}
+		if collection == "" {
+			return stats.CountOfDocuments, nil
+		}
+
+		// Collection names are case-insensitive in RavenDB
+		for name, count := range stats.Collections {
+			if strings.EqualFold(name, collection) {
+				return count, nil
+			}
+		}
+		return 0, nil
+	})
+}
+
+// countByQuery runs a count query (page size 0) filtered by the options' WhereClause and Parameters
+func countByQuery(database interfaces.IRavenDBService, collection string, options *interfaces.QueryOptions) (int, error) {
+	return runOperationResult(database, true, func() (int, error) {
+		store := database.GetStore().(*ravendb.DocumentStore)
+		session, err := store.OpenSession(database.GetDatabase())
+		if err != nil {
+			return 0, newOperationError("failed to open session", "", collection, "", err)
+		}
+		defer session.Close()
+
+		queryStr := fmt.Sprintf("from %s where (%s)", collectionSource(collection), options.WhereClause)
+		query := session.Advanced().RawQuery(queryStr)
+		for key, value := range options.Parameters {
+			query = query.AddParameter(key, value)
+		}
+
+		count, err := query.Count()
+		if err != nil {
+			return 0, newOperationError("failed to count documents", "", collection, queryStr, err)
+		}
+		return count, nil
+	})
+}
+
+// collectionSource returns the RQL source for a collection, or all documents when it is empty
+func collectionSource(collection string) string {
+	if collection == "" {
+		return "@all_docs"
+	}
+	return "'" + strings.ReplaceAll(collection, "'", "\\'") + "'"
+}
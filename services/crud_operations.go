@@ -1,232 +1,424 @@
 package services
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
 )
 
-// Store stores a document with the specified ID
-func (ds *DatabaseService) Store(id string, document interface{}) error {
-	store := ds.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(ds.GetDatabase())
-	if err != nil {
-		return fmt.Errorf("failed to open session: %w", err)
-	}
-	defer session.Close()
+// errNilDocument rejects nil documents before a session would
+var errNilDocument = errors.New("document cannot be nil")
 
-	// Use the proper Store API from the documentation
-	if id != "" {
-		err = session.StoreWithID(document, id)
-	} else {
-		err = session.Store(document)
+// Store stores a document with the specified ID, or a generated one when id is empty, and returns the
+// assigned ID and change vector. Struct documents must be pointers; their ID field receives the ID.
+func (ds *DatabaseService) Store(id string, document interface{}) (*interfaces.StoreResult, error) {
+	if document == nil {
+		return nil, newOperationError("failed to store document", id, "", "", errNilDocument)
 	}
+	entity := storableEntity(document)
 
-	if err != nil {
-		return fmt.Errorf("failed to store document: %w", err)
-	}
+	return runOperationResult(ds, id != "", func() (*interfaces.StoreResult, error) {
+		store := ds.GetStore().(*ravendb.DocumentStore)
+		session, err := store.OpenSession(ds.GetDatabase())
+		if err != nil {
+			return nil, newOperationError("failed to open session", "", "", "", err)
+		}
+		defer session.Close()
 
-	return session.SaveChanges()
-}
+		// Use the proper Store API from the documentation
+		if id != "" {
+			err = session.StoreWithID(entity, id)
+		} else {
+			err = session.Store(entity)
+		}
 
-// StoreMultiple stores multiple documents in a single transaction
-func (ds *DatabaseService) StoreMultiple(documents map[string]interface{}) error {
-	store := ds.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(ds.GetDatabase())
-	if err != nil {
-		return fmt.Errorf("failed to open session: %w", err)
-	}
-	defer session.Close()
+		if err != nil {
+			return nil, newOperationError("failed to store document", id, "", "", err)
+		}
+
+		if err := session.SaveChanges(); err != nil {
+			return nil, newOperationError("failed to save document", id, "", "", err)
+		}
+		return storedResult(session, entity), nil
+	})
+}
 
+// StoreMultiple stores multiple documents in a single transaction. The results are keyed like the
+// input, so the document stored under the empty key reports its generated ID.
+func (ds *DatabaseService) StoreMultiple(documents map[string]interface{}) (map[string]*interfaces.StoreResult, error) {
+	entities := make(map[string]interface{}, len(documents))
 	for id, document := range documents {
-		if id != "" {
-			err = session.StoreWithID(document, id)
-		} else {
-			err = session.Store(document)
+		if document == nil {
+			return nil, newOperationError("failed to store document", id, "", "", errNilDocument)
 		}
+		entities[id] = storableEntity(document)
+	}
+
+	return runOperationResult(ds, hasAllIDs(documents), func() (map[string]*interfaces.StoreResult, error) {
+		store := ds.GetStore().(*ravendb.DocumentStore)
+		session, err := store.OpenSession(ds.GetDatabase())
 		if err != nil {
-			return fmt.Errorf("failed to store document with ID %s: %w", id, err)
+			return nil, newOperationError("failed to open session", "", "", "", err)
+		}
+		defer session.Close()
+
+		for id, entity := range entities {
+			if id != "" {
+				err = session.StoreWithID(entity, id)
+			} else {
+				err = session.Store(entity)
+			}
+			if err != nil {
+				return nil, newOperationError("failed to store document", id, "", "", err)
+			}
+		}
+
+		if err := session.SaveChanges(); err != nil {
+			return nil, newOperationError("failed to save documents", "", "", "", err)
 		}
+
+		results := make(map[string]*interfaces.StoreResult, len(entities))
+		for id, entity := range entities {
+			results[id] = storedResult(session, entity)
+		}
+		return results, nil
+	})
+}
+
+// storableEntity returns a pointer to map documents, which the session tracks by identity and cannot compare by value
+func storableEntity(document interface{}) interface{} {
+	if m, ok := document.(map[string]interface{}); ok {
+		return &m
 	}
+	return document
+}
 
-	return session.SaveChanges()
+// storedResult reads the ID and change vector that SaveChanges assigned to a stored entity and
+// writes the ID back into the entity's ID field
+func storedResult(session *ravendb.DocumentSession, entity interface{}) *interfaces.StoreResult {
+	result := &interfaces.StoreResult{ID: session.Advanced().GetDocumentID(entity)}
+	setDocumentID(entity, result.ID)
+	if changeVector, err := session.Advanced().GetChangeVectorFor(entity); err == nil && changeVector != nil {
+		result.ChangeVector = *changeVector
+	}
+	return result
 }
 
 // LoadByID loads a document by ID into the result interface
 func (ds *DatabaseService) LoadByID(id string, result interface{}) error {
-	store := ds.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(ds.GetDatabase())
-	if err != nil {
-		return fmt.Errorf("failed to open session: %w", err)
-	}
-	defer session.Close()
+	return runOperation(ds, true, func() error {
+		store := ds.GetStore().(*ravendb.DocumentStore)
+		session, err := store.OpenSession(ds.GetDatabase())
+		if err != nil {
+			return newOperationError("failed to open session", "", "", "", err)
+		}
+		defer session.Close()
 
-	err = session.Load(result, id)
-	if err != nil {
-		return fmt.Errorf("failed to load document: %w", err)
-	}
+		err = session.Load(result, id)
+		if err != nil {
+			return newOperationError("failed to load document", id, "", "", err)
+		}
 
-	return nil
+		// Load leaves a **T result nil when the document does not exist
+		if value := reflect.ValueOf(result); value.Kind() == reflect.Ptr && !value.IsNil() {
+			if elem := value.Elem(); elem.Kind() == reflect.Ptr && elem.IsNil() {
+				return newNotFoundError("failed to load document", id, "")
+			}
+		}
+
+		return nil
+	})
 }
 
-// LoadMultipleByIDs loads multiple documents by their IDs
+// LoadMultipleByIDs loads multiple documents by their IDs in a single request. results must point to
+// a slice; documents are decoded into its element type in the requested order, skipping missing IDs.
 func (ds *DatabaseService) LoadMultipleByIDs(ids []string, results interface{}) error {
-	store := ds.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(ds.GetDatabase())
+	_, err := ds.LoadMultipleByIDsWithMissing(ids, results)
+	return err
+}
+
+// LoadMultipleByIDsWithMissing loads documents like LoadMultipleByIDs and returns the IDs that do not exist
+func (ds *DatabaseService) LoadMultipleByIDsWithMissing(ids []string, results interface{}) ([]string, error) {
+	target := reflect.ValueOf(results)
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Slice {
+		return nil, newOperationError("failed to load documents", "", "", "", fmt.Errorf("results must be a pointer to a slice, got %T", results))
+	}
+
+	documents, err := loadDocuments(ds, ids, false)
 	if err != nil {
-		return fmt.Errorf("failed to open session: %w", err)
+		return nil, newOperationError("failed to load documents", "", "", "", err)
 	}
-	defer session.Close()
 
-	// Load documents one by one and collect them
-	resultsSlice := reflect.ValueOf(results).Elem()
-	for _, id := range ids {
-		var doc interface{}
-		err = session.Load(&doc, id)
-		if err != nil {
-			return fmt.Errorf("failed to load document %s: %w", id, err)
+	slice := target.Elem()
+	loaded := reflect.MakeSlice(slice.Type(), 0, len(ids))
+	var missing []string
+	for i, document := range documents {
+		if document == nil {
+			missing = append(missing, ids[i])
+			continue
 		}
-		if doc != nil {
-			resultsSlice = reflect.Append(resultsSlice, reflect.ValueOf(doc))
+		value := reflect.New(slice.Type().Elem())
+		if err := remarshal(document, value.Interface()); err != nil {
+			return nil, newOperationError("failed to decode document", ids[i], "", "", err)
 		}
+		loaded = reflect.Append(loaded, value.Elem())
 	}
-	reflect.ValueOf(results).Elem().Set(resultsSlice)
+	slice.Set(loaded)
 
-	return nil
+	return missing, nil
 }
 
-// Update updates an existing document by loading, modifying, and saving
-func (ds *DatabaseService) Update(id string, updates map[string]interface{}) error {
-	store := ds.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(ds.GetDatabase())
-	if err != nil {
-		return fmt.Errorf("failed to open session: %w", err)
+// loadDocuments fetches documents by ID in one request. The result is aligned with ids, holding
+// nil for IDs that do not exist; repeated IDs are fetched once. With metadataOnly the documents
+// hold only their @metadata.
+func loadDocuments(db interfaces.IRavenDBService, ids []string, metadataOnly bool) ([]map[string]interface{}, error) {
+	documents := make([]map[string]interface{}, len(ids))
+	if len(ids) == 0 {
+		return documents, nil
 	}
-	defer session.Close()
 
-	// Use raw query to load the document as a generic map
-	query := session.Advanced().RawQuery("from @all_docs where id() = $id")
-	query = query.AddParameter("id", id)
+	var unique []string
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if key := strings.ToLower(id); id != "" && !seen[key] {
+			seen[key] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return documents, nil
+	}
 
-	var results []*map[string]interface{}
-	err = query.GetResults(&results)
+	var result *ravendb.GetDocumentsResult
+	err := runOperation(db, true, func() error {
+		command, err := ravendb.NewGetDocumentsCommand(unique, nil, metadataOnly)
+		if err != nil {
+			return err
+		}
+		store := db.GetStore().(*ravendb.DocumentStore)
+		if err := store.GetRequestExecutor(db.GetDatabase()).ExecuteCommand(command, nil); err != nil {
+			return err
+		}
+		result = command.Result
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to load document for update: %w", err)
+		return nil, err
 	}
 
-	if len(results) == 0 || results[0] == nil {
-		return fmt.Errorf("document with ID %s not found", id)
+	// A single missing ID is a 404 with no result; otherwise missing IDs are null results.
+	// IDs are case-insensitive, so match results on the lower-cased @id.
+	byID := make(map[string]map[string]interface{}, len(unique))
+	if result != nil {
+		for _, document := range result.Results {
+			if id := documentID(document); id != "" {
+				byID[strings.ToLower(id)] = document
+			}
+		}
+	}
+	for i, id := range ids {
+		documents[i] = byID[strings.ToLower(id)]
 	}
+	return documents, nil
+}
 
-	document := results[0]
+// documentID returns the @id from a raw document's metadata
+func documentID(document map[string]interface{}) string {
+	metadata, _ := document[ravendb.MetadataKey].(map[string]interface{})
+	id, _ := metadata[ravendb.MetadataID].(string)
+	return id
+}
 
-	// Apply updates
-	for key, value := range updates {
-		(*document)[key] = value
+// headDocument returns a document's change vector from a HEAD request, which transfers no body.
+// The change vector is empty if the document does not exist.
+func headDocument(db interfaces.IRavenDBService, id string) (string, error) {
+	if id == "" {
+		return "", fmt.Errorf("document ID cannot be empty")
 	}
 
-	// Store the updated document - let RavenDB handle the ID from the document
-	err = session.Store(document)
+	return runOperationResult(db, true, func() (string, error) {
+		command := ravendb.NewHeadDocumentCommand(id, nil)
+		store := db.GetStore().(*ravendb.DocumentStore)
+		if err := store.GetRequestExecutor(db.GetDatabase()).ExecuteCommand(command, nil); err != nil {
+			return "", err
+		}
+		if command.Result == nil {
+			return "", nil
+		}
+		return *command.Result, nil
+	})
+}
+
+// documentsExist returns the change vectors of the existing documents among ids from a single
+// metadata-only request
+func documentsExist(db interfaces.IRavenDBService, ids []string) (map[string]string, error) {
+	documents, err := loadDocuments(db, ids, true)
 	if err != nil {
-		return fmt.Errorf("failed to store updated document: %w", err)
+		return nil, err
 	}
 
-	return session.SaveChanges()
+	changeVectors := make(map[string]string, len(ids))
+	for i, document := range documents {
+		if document == nil {
+			continue
+		}
+		metadata, _ := document[ravendb.MetadataKey].(map[string]interface{})
+		changeVector, _ := metadata[ravendb.MetadataChangeVector].(string)
+		changeVectors[ids[i]] = changeVector
+	}
+	return changeVectors, nil
 }
 
-// Delete removes a document by ID
-func (ds *DatabaseService) Delete(id string) error {
-	store := ds.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(ds.GetDatabase())
-	if err != nil {
-		return fmt.Errorf("failed to open session: %w", err)
+// updateScript assigns each $updates entry to its dot-separated path, creating missing objects on the way
+const updateScript = `for (var path in $updates) {
+    var keys = path.split('.');
+    var target = this;
+    for (var i = 0; i < keys.length - 1; i++) {
+        if (target[keys[i]] === null || typeof target[keys[i]] !== 'object') {
+            target[keys[i]] = {};
+        }
+        target = target[keys[i]];
+    }
+    target[keys[keys.length - 1]] = $updates[path];
+}`
+
+// Update sets the given fields of an existing document in place with a server-side patch. Keys are
+// field names or dot-separated paths to nested fields, such as "address.city"; other fields, the
+// ID, the collection and the metadata are left untouched.
+func (ds *DatabaseService) Update(id string, updates map[string]interface{}) error {
+	for path := range updates {
+		if err := validateUpdatePath(path); err != nil {
+			return newOperationError("failed to update document", id, "", "", err)
+		}
+	}
+	if len(updates) == 0 {
+		return nil
 	}
-	defer session.Close()
 
-	// Use a simple generic struct for loading
-	type GenericDeleteDoc struct {
-		ID string `json:"@id"`
+	patch := &ravendb.PatchRequest{
+		Script: updateScript,
+		Values: map[string]interface{}{"updates": updates},
 	}
-	var document *GenericDeleteDoc
-	err = session.Load(&document, id)
+	operation, err := ravendb.NewPatchOperation(id, nil, patch, nil, false)
 	if err != nil {
-		return fmt.Errorf("failed to load document for deletion: %w", err)
+		return newOperationError("failed to update document", id, "", "", err)
 	}
 
-	if document == nil {
-		return fmt.Errorf("document with ID %s not found", id)
+	// Assigning the same values again is harmless, so the patch is retried
+	result, err := runOperationResult(ds, true, func() (*ravendb.PatchOperationResult, error) {
+		return ds.store.Operations().ForDatabase(ds.database).SendPatchOperation(operation, nil)
+	})
+	if err != nil {
+		return newOperationError("failed to update document", id, "", "", err)
+	}
+	if result.Status == ravendb.PatchStatusDocumentDoesNotExist {
+		return newNotFoundError("failed to update document", id, "")
 	}
+	return nil
+}
 
-	// Delete the loaded document
-	session.Delete(document)
-	return session.SaveChanges()
+// validateUpdatePath rejects empty path segments and metadata keys
+func validateUpdatePath(path string) error {
+	keys := strings.Split(path, ".")
+	for _, key := range keys {
+		if key == "" {
+			return fmt.Errorf("invalid field path %q", path)
+		}
+	}
+	if strings.HasPrefix(keys[0], "@") {
+		return fmt.Errorf("field path %q refers to metadata", path)
+	}
+	return nil
 }
 
-// DeleteMultiple removes multiple documents by their IDs
+// Delete removes a document by ID without loading it, returning an error matching interfaces.ErrNotFound if it does not exist
+func (ds *DatabaseService) Delete(id string) error {
+	return ds.DeleteWithOptions(id, nil)
+}
+
+// DeleteWithOptions removes a document by ID, optionally only if its change vector matches
+func (ds *DatabaseService) DeleteWithOptions(id string, options *interfaces.DeleteOptions) error {
+	return deleteOne(ds, id, "", options)
+}
+
+// DeleteMultiple removes multiple documents by their IDs, skipping IDs that do not exist
 func (ds *DatabaseService) DeleteMultiple(ids []string) error {
-	store := ds.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(ds.GetDatabase())
-	if err != nil {
-		return fmt.Errorf("failed to open session: %w", err)
-	}
-	defer session.Close()
+	_, err := ds.DeleteMultipleWithOptions(ids, nil)
+	return err
+}
+
+// DeleteMultipleWithOptions removes documents in one transaction and returns the IDs that existed
+func (ds *DatabaseService) DeleteMultipleWithOptions(ids []string, options *interfaces.DeleteOptions) ([]string, error) {
+	return deleteMany(ds, ids, "", options)
+}
 
-	// Use the same approach as single delete - load first, then delete
-	type GenericDeleteDoc struct {
-		ID string `json:"@id"`
+// deleteOne deletes a single document, reporting a missing one as not found
+func deleteOne(db interfaces.IRavenDBService, id, collection string, options *interfaces.DeleteOptions) error {
+	changeVectors := map[string]string{}
+	if options != nil && options.ChangeVector != "" {
+		changeVectors[id] = options.ChangeVector
 	}
 
-	for _, id := range ids {
-		var document *GenericDeleteDoc
-		err = session.Load(&document, id)
-		if err != nil {
-			return fmt.Errorf("failed to load document %s for deletion: %w", id, err)
-		}
+	deleted, err := deleteDocuments(db, []string{id}, changeVectors)
+	if err != nil {
+		return newOperationError("failed to delete document", id, collection, "", err)
+	}
+	if len(deleted) == 0 {
+		return newNotFoundError("failed to delete document", id, collection)
+	}
+	return nil
+}
 
-		if document != nil {
-			session.Delete(document)
-		}
-		// Skip if document doesn't exist instead of failing
+// deleteMany deletes documents in one transaction, using the options' per-ID change vectors
+func deleteMany(db interfaces.IRavenDBService, ids []string, collection string, options *interfaces.DeleteOptions) ([]string, error) {
+	var changeVectors map[string]string
+	if options != nil {
+		changeVectors = options.ChangeVectors
 	}
 
-	return session.SaveChanges()
+	deleted, err := deleteDocuments(db, ids, changeVectors)
+	if err != nil {
+		return nil, newOperationError("failed to delete documents", "", collection, "", err)
+	}
+	return deleted, nil
 }
 
 // Utility Methods
 
-// Exists checks if a document with the given ID exists
+// Exists checks if a document with the given ID exists using a HEAD request
 func (ds *DatabaseService) Exists(id string) (bool, error) {
-	store := ds.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(ds.GetDatabase())
+	changeVector, err := ds.ExistsWithChangeVector(id)
+	return changeVector != "", err
+}
+
+// ExistsWithChangeVector returns the document's current change vector, or an empty string if it does not exist
+func (ds *DatabaseService) ExistsWithChangeVector(id string) (string, error) {
+	changeVector, err := headDocument(ds, id)
 	if err != nil {
-		return false, fmt.Errorf("failed to open session: %w", err)
+		return "", newOperationError("failed to check document existence", id, "", "", err)
 	}
-	defer session.Close()
+	return changeVector, nil
+}
 
-	// Try to load as a generic struct - RavenDB will return nil if document doesn't exist
-	type GenericDoc struct {
-		ID string `json:"@id"`
-	}
-	var document *GenericDoc
-	err = session.Load(&document, id)
+// ExistsMany checks several documents in one metadata-only request, returning the change vectors of those that exist
+func (ds *DatabaseService) ExistsMany(ids []string) (map[string]string, error) {
+	changeVectors, err := documentsExist(ds, ids)
 	if err != nil {
-		return false, fmt.Errorf("failed to check document existence: %w", err)
+		return nil, newOperationError("failed to check document existence", "", "", "", err)
 	}
-
-	return document != nil, nil
+	return changeVectors, nil
 }
 
-// CountDocuments returns the total number of documents in a collection
+// CountDocuments returns the total number of documents in a collection, or in the database when collection is empty
 func (ds *DatabaseService) CountDocuments(collection string) (int, error) {
-	store := ds.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(ds.GetDatabase())
-	if err != nil {
-		return 0, fmt.Errorf("failed to open session: %w", err)
-	}
-	defer session.Close()
+	return countDocuments(ds, collection, nil)
+}
 
-	// Simplified implementation for now
-	// TODO: Implement proper document counting when RavenDB query API is clarified
-	return 0, nil
+// CountDocumentsWithOptions returns the number of documents in a collection matching the options' WhereClause
+func (ds *DatabaseService) CountDocumentsWithOptions(collection string, options *interfaces.QueryOptions) (int, error) {
+	return countDocuments(ds, collection, options)
 }
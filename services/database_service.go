@@ -1,7 +1,9 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/ravendb/ravendb-go-client"
 	"github.com/ternarybob/ravendb/interfaces"
@@ -11,49 +13,69 @@ import (
 type DatabaseService struct {
 	store    *ravendb.DocumentStore
 	database string
+	options  interfaces.DatabaseOptions
+	breaker  *circuitBreaker
+
+	// hilo holds the HiLo ID generator of each collection's ID prefix
+	hilo sync.Map
+
+	seedersMu sync.Mutex
+	seeders   []interfaces.ISeeder
+
+	migrationsMu sync.Mutex
+	migrations   []interfaces.Migration
 }
 
 // NewDatabaseService creates a new RavenDB database service
 func NewDatabaseService(urls []string, database string) (interfaces.IRavenDBService, error) {
+	return NewDatabaseServiceWithOptions(urls, database, nil)
+}
+
+// NewDatabaseServiceWithOptions creates a new RavenDB database service with a retry policy and circuit breaker
+func NewDatabaseServiceWithOptions(urls []string, database string, options *interfaces.DatabaseOptions) (interfaces.IRavenDBService, error) {
 	store := ravendb.NewDocumentStore(urls, database)
+	ds := &DatabaseService{
+		store:    store,
+		database: database,
+	}
 
 	// Configure for single-node development setup
 	store.GetConventions().SetDisableTopologyUpdates(true)
+	store.GetConventions().FindCollectionName = findCollectionName
 
 	// Initialize the document store
 	if err := store.Initialize(); err != nil {
-		return nil, fmt.Errorf("failed to initialize RavenDB store: %w", err)
+		return nil, newOperationError("failed to initialize RavenDB store", "", "", "", err)
 	}
 
-	return &DatabaseService{
-		store:    store,
-		database: database,
-	}, nil
+	if options != nil {
+		ds.options = *options
+		if options.CircuitBreaker != nil {
+			ds.breaker = newCircuitBreaker(options.CircuitBreaker)
+		}
+	}
+	return ds, nil
 }
 
 // Init initializes the RavenDB database with robust error handling
 func (ds *DatabaseService) Init() error {
 	fmt.Printf("Initializing RavenDB connection to: %v, database: %s\n", ds.store.GetUrls(), ds.database)
 
-	// First, try to create the database (this is safer than testing first)
-	fmt.Printf("Creating database '%s' if it doesn't exist...\n", ds.database)
-
-	databaseRecord := &ravendb.DatabaseRecord{
-		DatabaseName: ds.database,
-	}
-
-	// Use replication factor of 1 for single-node setup
-	operation := ravendb.NewCreateDatabaseOperation(databaseRecord, 1)
-	err := ds.store.Maintenance().Server().Send(operation)
+	// Create the database if it doesn't exist; genuine failures such as authorization errors abort initialization
+	created, err := NewAdminService(ds).EnsureDatabase(&interfaces.DatabaseCreateOptions{Name: ds.database})
 	if err != nil {
-		// Database might already exist, this is okay
-		fmt.Printf("Database creation result (might already exist): %v\n", err)
+		return err
+	}
+	if created {
+		fmt.Printf("Created database '%s'\n", ds.database)
+	} else {
+		fmt.Printf("Database '%s' already exists\n", ds.database)
 	}
 
 	// Now test if we can open a session to the specific database
 	session, err := ds.store.OpenSession(ds.database)
 	if err != nil {
-		return fmt.Errorf("failed to open session to database '%s': %w", ds.database, err)
+		return newOperationError(fmt.Sprintf("failed to open session to database '%s'", ds.database), "", "", "", err)
 	}
 	defer session.Close()
 
@@ -61,92 +83,59 @@ func (ds *DatabaseService) Init() error {
 	return nil
 }
 
-// InitializeWithSeeding initializes the database and optionally seeds it with data
+// InitializeWithSeeding initializes the database and optionally applies the registered seeders
 func (ds *DatabaseService) InitializeWithSeeding(seedData bool) error {
 	// First, initialize/create the database
 	if err := ds.Init(); err != nil {
 		return fmt.Errorf("database initialization failed: %w", err)
 	}
 
-	// If seeding is requested, check if database is empty
-	if seedData {
-		isEmpty, err := ds.isDatabaseEmpty()
-		if err != nil {
-			fmt.Printf("Warning: Could not check if database is empty: %v\n", err)
-			return nil // Continue without seeding
-		}
-
-		if isEmpty {
-			fmt.Println("Database is empty, automatic seeding would be performed here")
-			// Note: Actual seeding is handled by service layers
-		} else {
-			fmt.Println("Database contains data, skipping automatic seeding")
-		}
+	if !seedData {
+		return nil
 	}
 
-	return nil
-}
-
-// isDatabaseEmpty checks if the database has any documents
-func (ds *DatabaseService) isDatabaseEmpty() (bool, error) {
-	session, err := ds.store.OpenSession(ds.database)
-	if err != nil {
-		return false, fmt.Errorf("failed to open session: %w", err)
-	}
-	defer session.Close()
-
-	// Try to get database statistics to check document count
-	statisticsOperation := ravendb.NewGetStatisticsOperation("")
-	result := ds.store.Maintenance().ForDatabase(ds.database).Send(statisticsOperation)
-
-	// If we can't get statistics, assume database is empty
-	if result == nil {
-		return true, nil
-	}
-
-	// For now, we'll assume database is empty since statistics parsing is complex
-	return true, nil
+	return ds.runSeeders()
 }
 
 // Close closes the RavenDB connection
 func (ds *DatabaseService) Close() error {
+	ds.hilo.Range(func(_, generator interface{}) bool {
+		generator.(*ravendb.HiLoIDGenerator).ReturnUnusedRange()
+		return true
+	})
 	if ds.store != nil {
 		ds.store.Close()
 	}
 	return nil
 }
 
-// GetDatabaseStatus returns information about the RavenDB database state
+// GetDatabaseStatus returns information about the RavenDB database state in map form
 func (ds *DatabaseService) GetDatabaseStatus() (map[string]interface{}, error) {
-	status := make(map[string]interface{})
+	status, err := ds.GetStatus()
+	return status.ToMap(), err
+}
 
-	session, err := ds.store.OpenSession(ds.database)
-	if err != nil {
-		status["database_name"] = ds.database
-		status["status"] = "disconnected"
-		status["error"] = err.Error()
-		return status, err
+// GetStatus returns the database state and statistics. On failure the returned status is
+// still populated with the connection state and error.
+func (ds *DatabaseService) GetStatus() (*interfaces.DatabaseStatus, error) {
+	status := &interfaces.DatabaseStatus{
+		DatabaseName: ds.database,
+		Status:       interfaces.StatusConnected,
 	}
-	defer session.Close()
-
-	// Get database statistics
-	statisticsOperation := ravendb.NewGetStatisticsOperation("")
-	result := ds.store.Maintenance().ForDatabase(ds.database).Send(statisticsOperation)
 
-	if result == nil {
-		status["database_name"] = ds.database
-		status["status"] = "connected"
-		status["session_active"] = true
-		status["statistics_error"] = "failed to get statistics"
-		return status, nil
+	err := ds.loadStatistics(status)
+	if ds.breaker != nil {
+		status.CircuitBreaker = ds.breaker.status()
+	}
+	if err != nil {
+		// An open circuit means the server is known to be down and was not contacted
+		status.Status = interfaces.StatusDisconnected
+		if errors.Is(err, interfaces.ErrCircuitOpen) {
+			status.Status = interfaces.StatusUnavailable
+		}
+		status.Error = err.Error()
+		return status, err
 	}
-
-	// For now, provide basic status without detailed statistics
-	status["database_name"] = ds.database
-	status["status"] = "connected"
-	status["session_active"] = true
-	status["document_count"] = 0 // Placeholder
-	status["index_count"] = 0    // Placeholder
 
 	return status, nil
 }
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// newOperationError wraps a client error with the operation context and its sentinel classification.
+// Errors that are already OperationErrors are returned unchanged.
+func newOperationError(op, id, collection, query string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var opErr *interfaces.OperationError
+	if errors.As(err, &opErr) {
+		return err
+	}
+
+	return &interfaces.OperationError{
+		Op:         op,
+		ID:         id,
+		Collection: collection,
+		Query:      query,
+		Kind:       classifyError(err),
+		Err:        err,
+	}
+}
+
+// newNotFoundError reports a missing document
+func newNotFoundError(op, id, collection string) error {
+	return &interfaces.OperationError{
+		Op:         op,
+		ID:         id,
+		Collection: collection,
+		Kind:       interfaces.ErrNotFound,
+	}
+}
+
+// classifyError maps client errors to the library's sentinel errors, returning nil when none applies
+func classifyError(err error) error {
+	var (
+		concurrencyErr   *ravendb.ConcurrencyError
+		conflictErr      *ravendb.ConflictError
+		dbMissingErr     *ravendb.DatabaseDoesNotExistError
+		docMissingErr    *ravendb.DocumentDoesNotExistError
+		authorizationErr *ravendb.AuthorizationError
+		securityErr      *ravendb.SecurityError
+		timeoutErr       *ravendb.TimeoutError
+		loadTimeoutErr   *ravendb.DatabaseLoadTimeoutError
+		indexMissingErr  *ravendb.IndexDoesNotExistError
+		indexCompileErr  *ravendb.IndexCompilationError
+		indexCreateErr   *ravendb.IndexCreationError
+		indexInvalidErr  *ravendb.IndexInvalidError
+		indexExistsErr   *ravendb.IndexAlreadyExistError
+		indexDeleteErr   *ravendb.IndexDeletionError
+		nodesDownErr     *ravendb.AllTopologyNodesDownError
+		loadFailureErr   *ravendb.DatabaseLoadFailureError
+		serverLoadErr    *ravendb.ServerLoadFailureError
+		netErr           net.Error
+	)
+
+	switch {
+	case errors.Is(err, interfaces.ErrCircuitOpen):
+		return interfaces.ErrCircuitOpen
+	case errors.As(err, &concurrencyErr), errors.As(err, &conflictErr):
+		return interfaces.ErrConcurrencyConflict
+	case errors.As(err, &dbMissingErr):
+		return interfaces.ErrDatabaseDoesNotExist
+	case errors.As(err, &docMissingErr):
+		return interfaces.ErrNotFound
+	case errors.As(err, &authorizationErr), errors.As(err, &securityErr):
+		return interfaces.ErrUnauthorized
+	case errors.As(err, &timeoutErr), errors.As(err, &loadTimeoutErr), errors.Is(err, context.DeadlineExceeded):
+		return interfaces.ErrTimeout
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return interfaces.ErrTimeout
+	case errors.As(err, &nodesDownErr), errors.As(err, &loadFailureErr), errors.As(err, &serverLoadErr),
+		errors.As(err, &netErr):
+		return interfaces.ErrUnavailable
+	case errors.As(err, &indexMissingErr), errors.As(err, &indexCompileErr), errors.As(err, &indexCreateErr),
+		errors.As(err, &indexInvalidErr), errors.As(err, &indexExistsErr), errors.As(err, &indexDeleteErr):
+		return interfaces.ErrIndex
+	}
+
+	return nil
+}
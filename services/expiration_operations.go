@@ -0,0 +1,147 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// metadataRefresh is the metadata key read by the RavenDB refresh feature
+const metadataRefresh = "@refresh"
+
+// defaultFeatureFrequency is the server's default interval for expiration and refresh sweeps
+const defaultFeatureFrequency = 60 * time.Second
+
+// ConfigureExpiration enables, disables or tunes document expiration for the database
+func (ds *DatabaseService) ConfigureExpiration(options *interfaces.ExpirationOptions) error {
+	if options == nil {
+		options = &interfaces.ExpirationOptions{}
+	}
+
+	frequency := featureFrequency(options.DeleteFrequency)
+
+	var maxItems *int64
+	if options.MaxItemsToProcess > 0 {
+		maxItems = &options.MaxItemsToProcess
+	}
+
+	operation, err := ravendb.NewConfigureExpirationOperation(options.Disabled, &frequency, maxItems)
+	if err != nil {
+		return fmt.Errorf("failed to create expiration operation: %w", err)
+	}
+
+	err = ds.runOperation(true, func() error {
+		return ds.store.Maintenance().ForDatabase(ds.database).Send(operation)
+	})
+	if err != nil {
+		return newOperationError("failed to configure expiration", "", "", "", err)
+	}
+
+	return nil
+}
+
+// ConfigureRefresh enables, disables or tunes document refresh for the database
+func (ds *DatabaseService) ConfigureRefresh(options *interfaces.RefreshOptions) error {
+	if options == nil {
+		options = &interfaces.RefreshOptions{}
+	}
+
+	frequency := featureFrequency(options.RefreshFrequency)
+
+	operation := &configureRefreshOperation{
+		parameters: &refreshConfiguration{
+			Disabled:              options.Disabled,
+			RefreshFrequencyInSec: &frequency,
+		},
+	}
+
+	err := ds.runOperation(true, func() error {
+		return ds.store.Maintenance().ForDatabase(ds.database).Send(operation)
+	})
+	if err != nil {
+		return newOperationError("failed to configure refresh", "", "", "", err)
+	}
+
+	return nil
+}
+
+// featureFrequency converts a sweep interval to the whole seconds the server expects, rounding
+// up so that sub-second intervals do not become 0; zero or negative intervals use the default
+func featureFrequency(interval time.Duration) int64 {
+	if interval <= 0 {
+		interval = defaultFeatureFrequency
+	}
+	return int64((interval + time.Second - 1) / time.Second)
+}
+
+// resolveExpiration computes the absolute expiration and refresh times for a store call
+func resolveExpiration(options *interfaces.StoreOptions, defaultTTL time.Duration, now time.Time) (*time.Time, *time.Time) {
+	var expires, refresh *time.Time
+
+	if options != nil {
+		if options.Expires != nil {
+			expires = options.Expires
+		} else if options.ExpiresIn > 0 {
+			at := now.Add(options.ExpiresIn)
+			expires = &at
+		}
+
+		if options.Refresh != nil {
+			refresh = options.Refresh
+		} else if options.RefreshIn > 0 {
+			at := now.Add(options.RefreshIn)
+			refresh = &at
+		}
+	}
+
+	if expires == nil && defaultTTL > 0 {
+		at := now.Add(defaultTTL)
+		expires = &at
+	}
+
+	return expires, refresh
+}
+
+// refreshConfiguration mirrors the server's RefreshConfiguration record
+type refreshConfiguration struct {
+	Disabled              bool   `json:"Disabled"`
+	RefreshFrequencyInSec *int64 `json:"RefreshFrequencyInSec"`
+}
+
+// configureRefreshOperation posts a refresh configuration; the Go client has no built-in equivalent
+type configureRefreshOperation struct {
+	parameters *refreshConfiguration
+}
+
+// GetCommand returns the command that applies the refresh configuration
+func (o *configureRefreshOperation) GetCommand(conventions *ravendb.DocumentConventions) (ravendb.RavenCommand, error) {
+	data, err := json.Marshal(o.parameters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize refresh configuration: %w", err)
+	}
+
+	cmd := &configureRefreshCommand{
+		RavenCommandBase: ravendb.NewRavenCommandBase(),
+		parameters:       data,
+	}
+	cmd.ResponseType = ravendb.RavenCommandResponseTypeObject
+	return cmd, nil
+}
+
+type configureRefreshCommand struct {
+	ravendb.RavenCommandBase
+	parameters []byte
+}
+
+func (c *configureRefreshCommand) CreateRequest(node *ravendb.ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/admin/refresh/config"
+	return ravendb.NewHttpPost(url, c.parameters)
+}
+
+func (c *configureRefreshCommand) SetResponse(response []byte, fromCache bool) error {
+	return nil
+}
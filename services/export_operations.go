@@ -0,0 +1,609 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+const (
+	metadataCounters = "@counters" // Counter names, or name to value in exports; the client defines no constant
+
+	defaultTransferBatchSize        = 1000
+	defaultTransferProgressInterval = 1000
+)
+
+// Metadata written by the server that is meaningless in another database, so it is not exported
+var serverManagedMetadata = []string{
+	ravendb.MetadataChangeVector,
+	ravendb.MetadataLastModified,
+	ravendb.MetadataFlags,
+	"@timeseries",
+	"@index-score",
+}
+
+// exportedAttachment is an attachment embedded in an exported document's @metadata.@attachments
+type exportedAttachment struct {
+	Name        string `json:"Name"`
+	ContentType string `json:"ContentType,omitempty"`
+	Data        []byte `json:"Data"` // Base64 in JSON
+}
+
+// ExportToFile writes documents from the database to an NDJSON file, replacing it if it exists
+func ExportToFile(db interfaces.IRavenDBService, path string, options *interfaces.ExportOptions) (*interfaces.TransferResult, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	writer := bufio.NewWriter(file)
+	result, err := Export(db, writer, options)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to write export file: %w", err)
+	}
+	return result, nil
+}
+
+// Export streams documents from the database to w as NDJSON, one document per line with its
+// @metadata. Collections are exported one after another in name order.
+func Export(db interfaces.IRavenDBService, w io.Writer, options *interfaces.ExportOptions) (*interfaces.TransferResult, error) {
+	if options == nil {
+		options = &interfaces.ExportOptions{}
+	}
+
+	collections := options.Collections
+	if len(collections) == 0 {
+		var err error
+		if collections, err = userCollections(db); err != nil {
+			return nil, err
+		}
+	}
+
+	exporter := &exporter{
+		db:       db,
+		store:    db.GetStore().(*ravendb.DocumentStore),
+		encoder:  json.NewEncoder(w),
+		options:  options,
+		progress: newTransferProgress(options.Progress, options.ProgressInterval),
+	}
+	exporter.encoder.SetEscapeHTML(false)
+
+	started := time.Now()
+	for _, collection := range collections {
+		if err := exporter.exportCollection(collection); err != nil {
+			return exporter.progress.result(started), err
+		}
+	}
+
+	exporter.progress.complete()
+	return exporter.progress.result(started), nil
+}
+
+// userCollections lists the database's collections, excluding the library's system collection
+// and RavenDB's internal @-prefixed collections
+func userCollections(db interfaces.IRavenDBService) ([]string, error) {
+	status, err := db.GetStatus()
+	if err != nil {
+		return nil, err
+	}
+	if status.Error != "" {
+		return nil, fmt.Errorf("failed to list collections: %s", status.Error)
+	}
+
+	var collections []string
+	for name := range status.Collections {
+		if name != systemCollection && !strings.HasPrefix(name, "@") {
+			collections = append(collections, name)
+		}
+	}
+	sort.Strings(collections)
+	return collections, nil
+}
+
+// exporter writes the documents of one export
+type exporter struct {
+	db       interfaces.IRavenDBService
+	store    *ravendb.DocumentStore
+	encoder  *json.Encoder
+	options  *interfaces.ExportOptions
+	progress *transferProgress
+}
+
+// exportCollection streams one collection to the output. A stream cannot be resumed once
+// documents have been written, so it is not retried.
+func (e *exporter) exportCollection(collection string) error {
+	queryStr := "from " + collectionSource(collection)
+	if e.options.Where != "" {
+		queryStr += " where " + e.options.Where
+	}
+
+	return runOperation(e.db, false, func() error {
+		session, err := e.store.OpenSession(e.db.GetDatabase())
+		if err != nil {
+			return newOperationError("failed to open session", "", collection, "", err)
+		}
+		defer session.Close()
+
+		query := session.Advanced().RawQuery(queryStr)
+		for name, value := range e.options.Parameters {
+			query = query.AddParameter(name, value)
+		}
+
+		// The stream's entity results are discarded; the raw JSON is captured with its metadata
+		var raw map[string]interface{}
+		query.AddAfterStreamExecutedListener(func(document map[string]interface{}) {
+			raw = document
+		})
+
+		iterator, err := session.Advanced().StreamRawQuery(query, nil)
+		if err != nil {
+			return newOperationError("failed to stream collection", "", collection, queryStr, err)
+		}
+		defer iterator.Close()
+
+		for {
+			var discard *struct{}
+			if _, err := iterator.Next(&discard); err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				return newOperationError("failed to read exported document", "", collection, queryStr, err)
+			}
+
+			if err := e.writeDocument(collection, raw); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// writeDocument strips server-managed metadata, embeds attachments and counters if requested
+// and writes the document as one NDJSON line
+func (e *exporter) writeDocument(collection string, raw map[string]interface{}) error {
+	document := make(map[string]interface{}, len(raw))
+	for key, value := range raw {
+		document[key] = value
+	}
+
+	source, _ := raw[ravendb.MetadataKey].(map[string]interface{})
+	metadata := make(map[string]interface{}, len(source))
+	for key, value := range source {
+		metadata[key] = value
+	}
+	for _, key := range serverManagedMetadata {
+		delete(metadata, key)
+	}
+	document[ravendb.MetadataKey] = metadata
+
+	id, _ := metadata[ravendb.MetadataID].(string)
+	var attachments, counters int64
+
+	delete(metadata, ravendb.MetadataAttachments)
+	if names, _ := source[ravendb.MetadataAttachments].([]interface{}); e.options.IncludeAttachments && len(names) > 0 {
+		exported, err := e.loadAttachments(id, collection, names)
+		if err != nil {
+			return err
+		}
+		metadata[ravendb.MetadataAttachments] = exported
+		attachments = int64(len(exported))
+	}
+
+	delete(metadata, metadataCounters)
+	if names, _ := source[metadataCounters].([]interface{}); e.options.IncludeCounters && len(names) > 0 {
+		values, err := loadCounters(e.db, id)
+		if err != nil {
+			return newOperationError("failed to load counters", id, collection, "", err)
+		}
+		metadata[metadataCounters] = values
+		counters = int64(len(values))
+	}
+
+	if err := e.encoder.Encode(document); err != nil {
+		return fmt.Errorf("failed to write exported document %s: %w", id, err)
+	}
+
+	e.progress.add(collection, 1, attachments, counters)
+	return nil
+}
+
+// loadAttachments reads the contents of a document's attachments
+func (e *exporter) loadAttachments(id, collection string, names []interface{}) ([]exportedAttachment, error) {
+	var exported []exportedAttachment
+	for _, entry := range names {
+		details, _ := entry.(map[string]interface{})
+		name, _ := details["Name"].(string)
+		if name == "" {
+			continue
+		}
+
+		data, err := runOperationResult(e.db, true, func() ([]byte, error) {
+			operation := ravendb.NewGetAttachmentOperation(id, name, ravendb.AttachmentDocument, "", nil)
+			if err := e.store.Operations().ForDatabase(e.db.GetDatabase()).Send(operation, nil); err != nil {
+				return nil, err
+			}
+			attachment := operation.Command.Result
+			defer attachment.Close()
+			return io.ReadAll(attachment.Data)
+		})
+		if err != nil {
+			return nil, newOperationError("failed to load attachment "+name+" of", id, collection, "", err)
+		}
+
+		contentType, _ := details["ContentType"].(string)
+		exported = append(exported, exportedAttachment{Name: name, ContentType: contentType, Data: data})
+	}
+	return exported, nil
+}
+
+// ImportFromFile reads documents from an NDJSON file written by ExportToFile into the database
+func ImportFromFile(db interfaces.IRavenDBService, path string, options *interfaces.ImportOptions) (*interfaces.TransferResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer file.Close()
+
+	return Import(db, file, options)
+}
+
+// Import reads NDJSON documents written by Export from r and bulk-inserts them in batches,
+// restoring their @metadata and any embedded attachments and counters. Documents are stored
+// under their exported IDs unless options.RemapID returns another one.
+func Import(db interfaces.IRavenDBService, r io.Reader, options *interfaces.ImportOptions) (*interfaces.TransferResult, error) {
+	if options == nil {
+		options = &interfaces.ImportOptions{}
+	}
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultTransferBatchSize
+	}
+
+	collections := make(map[string]bool, len(options.Collections))
+	for _, collection := range options.Collections {
+		collections[strings.ToLower(collection)] = true
+	}
+
+	importer := &importer{
+		db:       db,
+		store:    db.GetStore().(*ravendb.DocumentStore),
+		progress: newTransferProgress(options.Progress, options.ProgressInterval),
+	}
+
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	started := time.Now()
+	var skipped int64
+	for line := 1; ; line++ {
+		var document map[string]interface{}
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return importer.result(started, skipped), fmt.Errorf("failed to parse import document %d: %w", line, err)
+		}
+
+		entry, err := newImportEntry(document)
+		if err != nil {
+			return importer.result(started, skipped), fmt.Errorf("invalid import document %d: %w", line, err)
+		}
+
+		if (len(collections) > 0 && !collections[strings.ToLower(entry.collection)]) ||
+			(options.Filter != nil && !options.Filter(entry.id, entry.collection, document)) {
+			skipped++
+			continue
+		}
+		if options.RemapID != nil {
+			if id := options.RemapID(entry.id, entry.collection); id != "" {
+				entry.setID(id)
+			}
+		}
+
+		importer.batch = append(importer.batch, entry)
+		if len(importer.batch) >= batchSize {
+			if err := importer.flush(); err != nil {
+				return importer.result(started, skipped), err
+			}
+		}
+	}
+
+	if err := importer.flush(); err != nil {
+		return importer.result(started, skipped), err
+	}
+
+	importer.progress.complete()
+	return importer.result(started, skipped), nil
+}
+
+// importEntry is a decoded document with its attachments and counters split from the metadata
+type importEntry struct {
+	id          string
+	collection  string
+	document    map[string]interface{}
+	attachments []exportedAttachment
+	counters    map[string]int64
+}
+
+// newImportEntry prepares an exported document for insertion. The server manages attachment and
+// counter metadata itself, so those entries are removed and restored separately after the insert.
+func newImportEntry(document map[string]interface{}) (*importEntry, error) {
+	metadata, _ := document[ravendb.MetadataKey].(map[string]interface{})
+	id, _ := metadata[ravendb.MetadataID].(string)
+	if id == "" {
+		return nil, fmt.Errorf("missing @metadata.@id")
+	}
+	collection, _ := metadata[ravendb.MetadataCollection].(string)
+
+	entry := &importEntry{id: id, collection: collection, document: document}
+	for _, key := range serverManagedMetadata {
+		delete(metadata, key)
+	}
+
+	if value, ok := metadata[ravendb.MetadataAttachments]; ok {
+		delete(metadata, ravendb.MetadataAttachments)
+		if err := remarshal(value, &entry.attachments); err != nil {
+			return nil, fmt.Errorf("invalid attachments: %w", err)
+		}
+	}
+
+	// Exports without counter values carry a list of names, which cannot be restored
+	if value, ok := metadata[metadataCounters]; ok {
+		delete(metadata, metadataCounters)
+		if values, isMap := value.(map[string]interface{}); isMap {
+			if err := remarshal(values, &entry.counters); err != nil {
+				return nil, fmt.Errorf("invalid counters: %w", err)
+			}
+		}
+	}
+
+	return entry, nil
+}
+
+// setID changes the ID the document is stored under
+func (e *importEntry) setID(id string) {
+	e.id = id
+	e.document[ravendb.MetadataKey].(map[string]interface{})[ravendb.MetadataID] = id
+}
+
+// remarshal converts a decoded JSON value into a typed destination
+func remarshal(value, destination interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, destination)
+}
+
+// importer writes the documents of one import
+type importer struct {
+	db       interfaces.IRavenDBService
+	store    *ravendb.DocumentStore
+	batch    []*importEntry
+	progress *transferProgress
+}
+
+// flush bulk-inserts the pending batch, then stores its attachments and counters, which
+// require the documents to exist. IDs are fixed, so a failed batch is safe to repeat.
+func (im *importer) flush() error {
+	if len(im.batch) == 0 {
+		return nil
+	}
+	database := im.db.GetDatabase()
+
+	err := runOperation(im.db, true, func() error {
+		bulkInsert := im.store.BulkInsert(database)
+		for _, entry := range im.batch {
+			if err := bulkInsert.StoreWithID(entry.document, entry.id, nil); err != nil {
+				_ = bulkInsert.Abort()
+				return newOperationError("failed to import document", entry.id, entry.collection, "", err)
+			}
+		}
+		if err := bulkInsert.Close(); err != nil {
+			return newOperationError("failed to complete import batch", "", "", "", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range im.batch {
+		for _, attachment := range entry.attachments {
+			err := runOperation(im.db, true, func() error {
+				operation := ravendb.NewPutAttachmentOperation(entry.id, attachment.Name, bytes.NewReader(attachment.Data), attachment.ContentType, nil)
+				return im.store.Operations().ForDatabase(database).Send(operation, nil)
+			})
+			if err != nil {
+				return newOperationError("failed to import attachment "+attachment.Name+" of", entry.id, entry.collection, "", err)
+			}
+		}
+
+		if len(entry.counters) > 0 {
+			if err := setCounters(im.db, entry.id, entry.counters); err != nil {
+				return newOperationError("failed to import counters", entry.id, entry.collection, "", err)
+			}
+		}
+
+		im.progress.add(entry.collection, 1, int64(len(entry.attachments)), int64(len(entry.counters)))
+	}
+
+	im.batch = im.batch[:0]
+	return nil
+}
+
+func (im *importer) result(started time.Time, skipped int64) *interfaces.TransferResult {
+	result := im.progress.result(started)
+	result.Skipped = skipped
+	return result
+}
+
+// transferProgress accumulates export and import totals and reports them at intervals
+type transferProgress struct {
+	report      func(interfaces.TransferProgress)
+	interval    int64
+	current     interfaces.TransferProgress
+	collections map[string]int64
+	lastReport  int64
+}
+
+func newTransferProgress(report func(interfaces.TransferProgress), interval int) *transferProgress {
+	if interval <= 0 {
+		interval = defaultTransferProgressInterval
+	}
+	return &transferProgress{report: report, interval: int64(interval), collections: make(map[string]int64)}
+}
+
+func (p *transferProgress) add(collection string, documents, attachments, counters int64) {
+	p.current.Collection = collection
+	p.current.Documents += documents
+	p.current.Attachments += attachments
+	p.current.Counters += counters
+	p.collections[collection] += documents
+
+	if p.report != nil && p.current.Documents-p.lastReport >= p.interval {
+		p.lastReport = p.current.Documents
+		p.report(p.current)
+	}
+}
+
+func (p *transferProgress) complete() {
+	p.current.Completed = true
+	if p.report != nil {
+		p.report(p.current)
+	}
+}
+
+func (p *transferProgress) result(started time.Time) *interfaces.TransferResult {
+	return &interfaces.TransferResult{
+		Documents:   p.current.Documents,
+		Attachments: p.current.Attachments,
+		Counters:    p.current.Counters,
+		Collections: p.collections,
+		Duration:    time.Since(started),
+	}
+}
+
+// loadCounters reads every counter of a document
+func loadCounters(db interfaces.IRavenDBService, id string) (map[string]int64, error) {
+	command := &getCountersCommand{RavenCommandBase: ravendb.NewRavenCommandBase(), documentID: id}
+	command.IsReadRequest = true
+
+	err := runOperation(db, true, func() error {
+		store := db.GetStore().(*ravendb.DocumentStore)
+		return store.GetRequestExecutor(db.GetDatabase()).ExecuteCommand(command, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]int64, len(command.result.Counters))
+	for _, counter := range command.result.Counters {
+		if counter != nil {
+			values[counter.CounterName] = counter.TotalValue
+		}
+	}
+	return values, nil
+}
+
+// setCounters sets a document's counters to the given values, replacing existing values
+func setCounters(db interfaces.IRavenDBService, id string, values map[string]int64) error {
+	document := counterOperations{DocumentID: id}
+	for name, value := range values {
+		document.Operations = append(document.Operations,
+			counterOperation{Type: "Delete", CounterName: name},
+			counterOperation{Type: "Increment", CounterName: name, Delta: value},
+		)
+	}
+
+	data, err := json.Marshal(counterBatch{Documents: []counterOperations{document}})
+	if err != nil {
+		return fmt.Errorf("failed to serialize counter batch: %w", err)
+	}
+
+	command := &counterBatchCommand{RavenCommandBase: ravendb.NewRavenCommandBase(), parameters: data}
+	command.ResponseType = ravendb.RavenCommandResponseTypeObject
+
+	// Deleting before incrementing makes the batch safe to repeat
+	return runOperation(db, true, func() error {
+		store := db.GetStore().(*ravendb.DocumentStore)
+		return store.GetRequestExecutor(db.GetDatabase()).ExecuteCommand(command, nil)
+	})
+}
+
+// countersDetail mirrors the server's CountersDetail response
+type countersDetail struct {
+	Counters []*struct {
+		DocumentID  string `json:"DocumentId"`
+		CounterName string `json:"CounterName"`
+		TotalValue  int64  `json:"TotalValue"`
+	} `json:"Counters"`
+}
+
+// getCountersCommand reads all counters of a document; the Go client has no counters support
+type getCountersCommand struct {
+	ravendb.RavenCommandBase
+	documentID string
+	result     countersDetail
+}
+
+func (c *getCountersCommand) CreateRequest(node *ravendb.ServerNode) (*http.Request, error) {
+	endpoint := node.URL + "/databases/" + node.Database + "/counters?docId=" + url.QueryEscape(c.documentID)
+	return http.NewRequest(http.MethodGet, endpoint, nil)
+}
+
+func (c *getCountersCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return nil
+	}
+	return json.Unmarshal(response, &c.result)
+}
+
+// counterBatch mirrors the server's CounterBatch record
+type counterBatch struct {
+	ReplyWithAllNodesValues bool                `json:"ReplyWithAllNodesValues"`
+	Documents               []counterOperations `json:"Documents"`
+}
+
+type counterOperations struct {
+	DocumentID string             `json:"DocumentId"`
+	Operations []counterOperation `json:"Operations"`
+}
+
+type counterOperation struct {
+	Type        string `json:"Type"`
+	CounterName string `json:"CounterName"`
+	Delta       int64  `json:"Delta,omitempty"`
+}
+
+// counterBatchCommand applies counter operations to documents
+type counterBatchCommand struct {
+	ravendb.RavenCommandBase
+	parameters []byte
+}
+
+func (c *counterBatchCommand) CreateRequest(node *ravendb.ServerNode) (*http.Request, error) {
+	return ravendb.NewHttpPost(node.URL+"/databases/"+node.Database+"/counters", c.parameters)
+}
+
+func (c *counterBatchCommand) SetResponse(response []byte, fromCache bool) error {
+	return nil
+}
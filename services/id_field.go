@@ -0,0 +1,89 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// tagName is the struct tag holding the library's field options, e.g. `ravendb:"id"`
+const tagName = "ravendb"
+
+// idFields caches the identity field index of each struct type; nil means the type has none
+var idFields sync.Map // reflect.Type -> []int
+
+// idFieldIndex returns the index of a struct type's identity field: the string field tagged
+// `ravendb:"id"`, or else the field named ID, which is the client's convention
+func idFieldIndex(typ reflect.Type) []int {
+	if cached, ok := idFields.Load(typ); ok {
+		return cached.([]int)
+	}
+
+	var index []int
+	if field, ok := taggedField(typ, "id"); ok && field.Type.Kind() == reflect.String {
+		index = field.Index
+	} else if field, ok := typ.FieldByName("ID"); ok && field.IsExported() && field.Type.Kind() == reflect.String {
+		index = field.Index
+	}
+
+	idFields.Store(typ, index)
+	return index
+}
+
+// taggedField finds the exported field whose ravendb tag includes the option
+func taggedField(typ reflect.Type, option string) (reflect.StructField, bool) {
+	for _, field := range reflect.VisibleFields(typ) {
+		if !field.IsExported() {
+			continue
+		}
+		if _, ok := tagOptions(field.Tag.Get(tagName))[option]; ok {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// tagOptions parses a ravendb tag of comma-separated options, each a name or name=value
+func tagOptions(tag string) map[string]string {
+	options := make(map[string]string)
+	for _, option := range strings.Split(tag, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		if name != "" {
+			options[name] = strings.TrimSpace(value)
+		}
+	}
+	return options
+}
+
+// idField returns the settable identity field of a pointer to a struct, if it has one
+func idField(document interface{}) (reflect.Value, bool) {
+	value := reflect.ValueOf(document)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	index := idFieldIndex(value.Elem().Type())
+	if index == nil {
+		return reflect.Value{}, false
+	}
+	field, err := value.Elem().FieldByIndexErr(index)
+	if err != nil || !field.CanSet() {
+		return reflect.Value{}, false
+	}
+	return field, true
+}
+
+// documentIDOf reads a document's identity field, returning "" if it has none
+func documentIDOf(document interface{}) string {
+	if field, ok := idField(document); ok {
+		return field.String()
+	}
+	return ""
+}
+
+// setDocumentID writes an ID into a document's identity field, if it has one
+func setDocumentID(document interface{}, id string) {
+	if field, ok := idField(document); ok && id != "" {
+		field.SetString(id)
+	}
+}
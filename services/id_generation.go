@@ -0,0 +1,131 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// resolveID returns the ID to store a document under: the given one, else the document's ID field,
+// else one from the collection's ID strategy
+func (cs *CollectionService[T]) resolveID(id string, document *T) (string, error) {
+	if id == "" {
+		id = documentIDOf(document)
+	}
+	if id != "" {
+		return id, nil
+	}
+	return cs.generateID(document)
+}
+
+// resolveRawID is resolveID for writes that bypass the session, which cannot leave HiLo IDs to
+// the client, so the ID is reserved from the store's HiLo generator instead when the database
+// service has no generator of its own
+func (cs *CollectionService[T]) resolveRawID(id string, document *T) (string, error) {
+	id, err := cs.resolveID(id, document)
+	if err != nil || id != "" {
+		return id, err
+	}
+	store := cs.database.GetStore().(*ravendb.DocumentStore)
+	return store.GetConventions().GenerateDocumentID(cs.database.GetDatabase(), document)
+}
+
+// generateID returns the ID for a document stored without one. Identity IDs are left to the server,
+// so that strategy returns a "prefix|" placeholder. HiLo IDs are reserved under the collection's
+// prefix, or left to the client, returning an empty ID, if the database service cannot reserve them.
+func (cs *CollectionService[T]) generateID(document *T) (string, error) {
+	if cs.options.IDGenerator != nil {
+		id, err := cs.options.IDGenerator(document)
+		if err != nil {
+			return "", err
+		}
+		if id == "" {
+			return "", fmt.Errorf("ID generator returned an empty ID")
+		}
+		return id, nil
+	}
+
+	prefix := documentIDPrefix(cs.collection)
+	switch cs.options.IDStrategy {
+	case "", interfaces.IDStrategyHiLo:
+		if source, ok := cs.database.(hiloSource); ok {
+			return source.hiloID(prefix)
+		}
+		return "", nil
+	case interfaces.IDStrategyIdentity:
+		return prefix + "|", nil
+	case interfaces.IDStrategyGUID:
+		return prefix + "/" + uuid.NewString(), nil
+	case interfaces.IDStrategyULID:
+		return prefix + "/" + newULID(time.Now()), nil
+	}
+	return "", fmt.Errorf("unknown ID strategy %q", cs.options.IDStrategy)
+}
+
+// hiloSource is implemented by database services that reserve HiLo ID ranges per ID prefix.
+// The store's own generator takes the prefix from the entity's type, not its collection service.
+type hiloSource interface {
+	hiloID(prefix string) (string, error)
+}
+
+// hiloID returns the next HiLo ID for a prefix, reserving a new range from the server when needed
+func (ds *DatabaseService) hiloID(prefix string) (string, error) {
+	generator, ok := ds.hilo.Load(prefix)
+	if !ok {
+		separator := ds.store.GetConventions().GetIdentityPartsSeparator()
+		generator, _ = ds.hilo.LoadOrStore(prefix, ravendb.NewHiLoIDGenerator(prefix, ds.store, ds.database, separator))
+	}
+	// Reserving another range on a retry only leaves a gap in the IDs
+	return runOperationResult(ds, true, func() (string, error) {
+		return generator.(*ravendb.HiLoIDGenerator).GenerateDocumentID(nil)
+	})
+}
+
+// isFinalID reports whether a document ID is known before saving, which makes storing it safe to repeat
+func isFinalID(id string) bool {
+	return id != "" && !strings.HasSuffix(id, "|")
+}
+
+// documentIDPrefix mirrors the client's default prefix convention: a collection name with a single
+// capital letter is lower-cased ("Users" becomes "users"), any other name is kept as is
+func documentIDPrefix(collection string) string {
+	upper := 0
+	for _, c := range collection {
+		if unicode.IsUpper(c) {
+			upper++
+		}
+	}
+	if upper == 1 {
+		return strings.ToLower(collection)
+	}
+	return collection
+}
+
+// crockford is the ULID alphabet
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newULID returns a ULID: a 48-bit millisecond timestamp followed by 80 random bits, encoded as
+// 26 Crockford base32 characters so that IDs sort by creation time
+func newULID(now time.Time) string {
+	var data [16]byte
+	binary.BigEndian.PutUint64(data[:8], uint64(now.UnixMilli())<<16)
+	rand.Read(data[6:]) // never fails since Go 1.24
+
+	// 128 bits in 26 characters of 5 bits, the first holding the top 3 bits
+	var encoded [26]byte
+	hi := binary.BigEndian.Uint64(data[:8])
+	lo := binary.BigEndian.Uint64(data[8:])
+	for i := 25; i >= 0; i-- {
+		encoded[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(encoded[:])
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// GetIndexes returns every index in the database with its statistics, ordered by name
+func (ds *DatabaseService) GetIndexes() ([]interfaces.IndexInfo, error) {
+	operation := ravendb.NewGetIndexesStatisticsOperation()
+	err := ds.runOperation(true, func() error {
+		return ds.store.Maintenance().ForDatabase(ds.database).Send(operation)
+	})
+	if err != nil {
+		return nil, newOperationError("failed to list indexes", "", "", "", err)
+	}
+
+	indexes := make([]interfaces.IndexInfo, 0, len(operation.Command.Result))
+	for _, stats := range operation.Command.Result {
+		info := interfaces.IndexInfo{
+			Name:             stats.Name,
+			Type:             stats.Type,
+			State:            stats.State,
+			Priority:         stats.Priority,
+			LockMode:         stats.LockMode,
+			IsStale:          stats.IsStale,
+			EntriesCount:     stats.EntriesCount,
+			ErrorsCount:      stats.ErrorsCount,
+			LastIndexingTime: optionalTime(stats.LastIndexingTime),
+			LastQueryingTime: optionalTime(stats.LastQueryingTime),
+		}
+		for collection := range stats.Collections {
+			info.Collections = append(info.Collections, collection)
+		}
+		sort.Strings(info.Collections)
+		indexes = append(indexes, info)
+	}
+
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i].Name < indexes[j].Name })
+	return indexes, nil
+}
+
+// ResetIndex discards an index's entries and rebuilds it from scratch
+func (ds *DatabaseService) ResetIndex(name string) error {
+	operation, err := ravendb.NewResetIndexOperation(name)
+	if err != nil {
+		return err
+	}
+
+	err = ds.runOperation(true, func() error {
+		return ds.store.Maintenance().ForDatabase(ds.database).Send(operation)
+	})
+	if err != nil {
+		return newOperationError("failed to reset index "+name, "", "", "", err)
+	}
+	return nil
+}
+
+// optionalTime converts a client timestamp, returning nil for the zero time
+func optionalTime(t ravendb.Time) *time.Time {
+	value := time.Time(t)
+	if value.IsZero() {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// applyStoreMetadata sets the collection, custom metadata, @expires and @refresh on an entity already
+// stored in the session. The collection overrides the one the store derives from the entity's type.
+func applyStoreMetadata(session *ravendb.DocumentSession, entity interface{}, collection string, options *interfaces.StoreOptions, defaultTTL time.Duration) error {
+	var custom map[string]interface{}
+	if options != nil {
+		custom = options.Metadata
+	}
+	if err := validateCustomMetadata(custom); err != nil {
+		return err
+	}
+
+	expires, refresh := resolveExpiration(options, defaultTTL, time.Now())
+	if collection == "" && expires == nil && refresh == nil && len(custom) == 0 {
+		return nil
+	}
+
+	metadata, err := session.Advanced().GetMetadataFor(entity)
+	if err != nil {
+		return fmt.Errorf("failed to get document metadata: %w", err)
+	}
+
+	if collection != "" {
+		metadata.Put(ravendb.MetadataCollection, collection)
+	}
+	for key, value := range custom {
+		metadata.Put(key, value)
+	}
+	if expires != nil {
+		metadata.Put(ravendb.MetadataExpires, ravendb.Time(expires.UTC()).Format())
+	}
+	if refresh != nil {
+		metadata.Put(metadataRefresh, ravendb.Time(refresh.UTC()).Format())
+	}
+
+	return nil
+}
+
+// validateCustomMetadata rejects custom metadata keys that are empty or reserved by RavenDB
+func validateCustomMetadata(metadata map[string]interface{}) error {
+	for key := range metadata {
+		if key == "" || strings.HasPrefix(key, "@") {
+			return fmt.Errorf("invalid custom metadata key %q: keys must be non-empty and not start with \"@\"", key)
+		}
+	}
+	return nil
+}
+
+// parseMetadata converts a raw document's @metadata to its typed form
+func parseMetadata(document map[string]interface{}) interfaces.DocumentMetadata {
+	raw, _ := document[ravendb.MetadataKey].(map[string]interface{})
+
+	metadata := interfaces.DocumentMetadata{}
+	metadata.ID, _ = raw[ravendb.MetadataID].(string)
+	metadata.Collection, _ = raw[ravendb.MetadataCollection].(string)
+	metadata.ChangeVector, _ = raw[ravendb.MetadataChangeVector].(string)
+	if lastModified := metadataTime(raw, ravendb.MetadataLastModified); lastModified != nil {
+		metadata.LastModified = *lastModified
+	}
+	metadata.Expires = metadataTime(raw, ravendb.MetadataExpires)
+	metadata.Refresh = metadataTime(raw, metadataRefresh)
+
+	if flags, ok := raw[ravendb.MetadataFlags].(string); ok {
+		for _, flag := range strings.Split(flags, ",") {
+			if flag = strings.TrimSpace(flag); flag != "" {
+				metadata.Flags = append(metadata.Flags, flag)
+			}
+		}
+	}
+
+	// The client's own type hint is not a custom entry
+	for key, value := range raw {
+		if strings.HasPrefix(key, "@") || key == ravendb.MetadataRavenGoType {
+			continue
+		}
+		if metadata.Custom == nil {
+			metadata.Custom = make(map[string]interface{})
+		}
+		metadata.Custom[key] = value
+	}
+
+	return metadata
+}
+
+// metadataTime parses a timestamp metadata entry, returning nil if it is missing or malformed
+func metadataTime(metadata map[string]interface{}, key string) *time.Time {
+	value, ok := metadata[key].(string)
+	if !ok {
+		return nil
+	}
+	parsed, err := ravendb.ParseTime(value)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
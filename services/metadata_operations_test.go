@@ -0,0 +1,28 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMetadata(t *testing.T) {
+	metadata := parseMetadata(map[string]interface{}{
+		"name": "Ada",
+		ravendb.MetadataKey: map[string]interface{}{
+			ravendb.MetadataID:           "users/1",
+			ravendb.MetadataCollection:   "Users",
+			ravendb.MetadataChangeVector: "A:1-abc",
+			ravendb.MetadataFlags:        "HasRevisions, HasAttachments",
+			ravendb.MetadataRavenGoType:  "ravendb.TestUser",
+			"tenantId":                   "acme",
+		},
+	})
+
+	assert.Equal(t, "users/1", metadata.ID)
+	assert.Equal(t, "Users", metadata.Collection)
+	assert.Equal(t, "A:1-abc", metadata.ChangeVector)
+	assert.Equal(t, []string{"HasRevisions", "HasAttachments"}, metadata.Flags)
+	assert.Equal(t, map[string]interface{}{"tenantId": "acme"}, metadata.Custom, "Only user entries are custom")
+}
@@ -0,0 +1,450 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+const (
+	// migrationRegistryID is the system document recording applied migrations
+	migrationRegistryID = "system/migrations"
+	// migrationLockKey is the compare-exchange key holding the cluster-wide migration lock
+	migrationLockKey = "system/migrations/lock"
+	// defaultMigrationLockTTL is the age after which a lock left by a crashed instance can be taken over
+	defaultMigrationLockTTL = 15 * time.Minute
+)
+
+// migrationRegistry is the stored form of the applied migrations
+type migrationRegistry struct {
+	Applied []interfaces.AppliedMigration `json:"applied"`
+}
+
+// migrationLock is the compare-exchange value identifying the instance running migrations
+type migrationLock struct {
+	Owner      string    `json:"owner"`
+	Token      string    `json:"token"` // Identifies one acquisition, so a retried put recognises its own lock
+	AcquiredAt time.Time `json:"acquiredAt"`
+	RenewedAt  time.Time `json:"renewedAt"`
+}
+
+// migrationLease is a held migration lock, renewed in the background until released so that a
+// long-running migration is not mistaken for an abandoned one
+type migrationLease struct {
+	ds *DatabaseService
+
+	mu    sync.Mutex
+	lock  migrationLock
+	index int64
+	lost  error
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// RegisterMigration adds a migration; versions must be positive and unique
+func (ds *DatabaseService) RegisterMigration(migration interfaces.Migration) error {
+	if migration.Version <= 0 {
+		return fmt.Errorf("migration version must be positive, got %d", migration.Version)
+	}
+	if migration.Up == nil {
+		return fmt.Errorf("migration %d has no Up function", migration.Version)
+	}
+
+	ds.migrationsMu.Lock()
+	defer ds.migrationsMu.Unlock()
+
+	for _, existing := range ds.migrations {
+		if existing.Version == migration.Version {
+			return fmt.Errorf("migration version %d is already registered as %q", migration.Version, existing.Name)
+		}
+	}
+	ds.migrations = append(ds.migrations, migration)
+	return nil
+}
+
+// Migrate applies pending migrations in version order while holding the cluster-wide migration lock.
+// Each migration is recorded as soon as it succeeds, so a failed run resumes after the last success.
+func (ds *DatabaseService) Migrate(options *interfaces.MigrateOptions) (*interfaces.MigrationReport, error) {
+	if options == nil {
+		options = &interfaces.MigrateOptions{}
+	}
+
+	var lease *migrationLease
+	if !options.DryRun {
+		var err error
+		if lease, err = ds.acquireMigrationLock(options); err != nil {
+			return nil, err
+		}
+		defer lease.release()
+	}
+
+	registry, err := loadSystemDocument[migrationRegistry](ds, migrationRegistryID)
+	if err != nil {
+		return nil, err
+	}
+
+	current := currentMigrationVersion(registry)
+	report := &interfaces.MigrationReport{
+		DryRun:      options.DryRun,
+		FromVersion: current,
+		ToVersion:   current,
+	}
+
+	for _, migration := range ds.pendingMigrations(registry, options.TargetVersion) {
+		if lease != nil {
+			if err := lease.err(); err != nil {
+				return report, err
+			}
+		}
+
+		ctx := &migrationContext{db: ds, dryRun: options.DryRun, migration: migration, report: report}
+		started := time.Now()
+
+		if err := migration.Up(ctx); err != nil {
+			return report, fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Name, err)
+		}
+
+		applied := interfaces.AppliedMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			AppliedAt: time.Now().UTC(),
+			Duration:  time.Since(started),
+		}
+		if !options.DryRun {
+			err := updateSystemDocument(ds, migrationRegistryID, func(registry *migrationRegistry) {
+				registry.Applied = append(registry.Applied, applied)
+			})
+			if err != nil {
+				return report, fmt.Errorf("migration %d (%s) succeeded but could not be recorded: %w", migration.Version, migration.Name, err)
+			}
+		}
+
+		report.Applied = append(report.Applied, applied)
+		if migration.Version > report.ToVersion {
+			report.ToVersion = migration.Version
+		}
+	}
+
+	return report, nil
+}
+
+// GetMigrationStatus reports the applied migrations and the registered migrations still pending
+func (ds *DatabaseService) GetMigrationStatus() (*interfaces.MigrationStatus, error) {
+	registry, err := loadSystemDocument[migrationRegistry](ds, migrationRegistryID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &interfaces.MigrationStatus{
+		CurrentVersion: currentMigrationVersion(registry),
+		Applied:        registry.Applied,
+		Pending:        []interfaces.MigrationInfo{},
+	}
+	for _, migration := range ds.pendingMigrations(registry, 0) {
+		status.Pending = append(status.Pending, interfaces.MigrationInfo{Version: migration.Version, Name: migration.Name})
+	}
+
+	return status, nil
+}
+
+// pendingMigrations returns the registered migrations not yet applied, up to target (0 for all), in version order
+func (ds *DatabaseService) pendingMigrations(registry *migrationRegistry, target int64) []interfaces.Migration {
+	applied := make(map[int64]bool, len(registry.Applied))
+	for _, migration := range registry.Applied {
+		applied[migration.Version] = true
+	}
+
+	ds.migrationsMu.Lock()
+	defer ds.migrationsMu.Unlock()
+
+	var pending []interfaces.Migration
+	for _, migration := range ds.migrations {
+		if !applied[migration.Version] && (target <= 0 || migration.Version <= target) {
+			pending = append(pending, migration)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].Version < pending[j].Version })
+	return pending
+}
+
+// currentMigrationVersion returns the highest applied version
+func currentMigrationVersion(registry *migrationRegistry) int64 {
+	var current int64
+	for _, migration := range registry.Applied {
+		if migration.Version > current {
+			current = migration.Version
+		}
+	}
+	return current
+}
+
+// acquireMigrationLock takes the cluster-wide migration lock, taking over a lock not renewed
+// within the TTL, and returns a lease that keeps renewing it until released
+func (ds *DatabaseService) acquireMigrationLock(options *interfaces.MigrateOptions) (*migrationLease, error) {
+	owner := options.Owner
+	if owner == "" {
+		host, _ := os.Hostname()
+		owner = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	ttl := options.LockTTL
+	if ttl <= 0 {
+		ttl = defaultMigrationLockTTL
+	}
+
+	now := time.Now().UTC()
+	lock := migrationLock{Owner: owner, Token: uuid.NewString(), AcquiredAt: now, RenewedAt: now}
+
+	// Index 0 only succeeds when no lock exists
+	index, acquired, err := ds.putMigrationLock(&lock, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if !acquired {
+		held, heldIndex, err := ds.getMigrationLock()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case held != nil && held.Owner == lock.Owner && held.Token == lock.Token:
+			// A retried put whose first attempt succeeded finds our own lock
+			index, acquired = heldIndex, true
+		case held != nil && time.Since(held.lastRenewed()) < ttl:
+			return nil, &interfaces.OperationError{
+				Op:   "failed to acquire migration lock",
+				Kind: interfaces.ErrMigrationLocked,
+				Err:  fmt.Errorf("held by %s since %s", held.Owner, held.AcquiredAt.Format(time.RFC3339)),
+			}
+		default:
+			// The lock is stale (or vanished); take it over only if nobody else changed it meanwhile
+			index, acquired, err = ds.putMigrationLock(&lock, heldIndex)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !acquired {
+			return nil, &interfaces.OperationError{
+				Op:   "failed to acquire migration lock",
+				Kind: interfaces.ErrMigrationLocked,
+			}
+		}
+	}
+
+	lease := &migrationLease{
+		ds:    ds,
+		lock:  lock,
+		index: index,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go lease.renew(ttl / 3)
+	return lease, nil
+}
+
+// lastRenewed returns when the lock was last known to be alive
+func (l *migrationLock) lastRenewed() time.Time {
+	if l.RenewedAt.After(l.AcquiredAt) {
+		return l.RenewedAt
+	}
+	return l.AcquiredAt
+}
+
+// renew refreshes the lock every interval until the lease is released or the lock is lost.
+// Failed renewals are retried on the next tick; a lock taken over by another instance is lost.
+func (l *migrationLease) renew(interval time.Duration) {
+	defer close(l.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+
+		l.mu.Lock()
+		lock := l.lock
+		lock.RenewedAt = time.Now().UTC()
+		index, acquired, err := l.ds.putMigrationLock(&lock, l.index)
+		switch {
+		case err != nil:
+			fmt.Printf("Warning: failed to renew migration lock: %v\n", err)
+		case !acquired:
+			l.lost = &interfaces.OperationError{
+				Op:   "lost migration lock",
+				Kind: interfaces.ErrMigrationLocked,
+				Err:  fmt.Errorf("the lock was taken over by another instance"),
+			}
+		default:
+			l.lock, l.index = lock, index
+		}
+		lost := l.lost != nil
+		l.mu.Unlock()
+
+		if lost {
+			return
+		}
+	}
+}
+
+// err reports whether the lock has been lost to another instance
+func (l *migrationLease) err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lost
+}
+
+// release stops renewing and deletes the lock, unless it was lost to another instance
+func (l *migrationLease) release() {
+	close(l.stop)
+	<-l.done
+
+	if l.err() != nil {
+		return
+	}
+	operation, err := ravendb.NewDeleteCompareExchangeValueOperation(reflect.TypeOf(&migrationLock{}), migrationLockKey, l.index)
+	if err != nil {
+		return
+	}
+	if err := l.ds.runOperation(true, func() error {
+		return l.ds.store.Operations().ForDatabase(l.ds.database).Send(operation, nil)
+	}); err != nil {
+		fmt.Printf("Warning: failed to release migration lock: %v\n", err)
+	}
+}
+
+// putMigrationLock writes the lock if its current index matches, returning the new index
+func (ds *DatabaseService) putMigrationLock(lock *migrationLock, index int64) (int64, bool, error) {
+	operation, err := ravendb.NewPutCompareExchangeValueOperation(migrationLockKey, lock, index)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create migration lock operation: %w", err)
+	}
+
+	err = ds.runOperation(true, func() error {
+		return ds.store.Operations().ForDatabase(ds.database).Send(operation, nil)
+	})
+	if err != nil {
+		return 0, false, newOperationError("failed to acquire migration lock", migrationLockKey, "", "", err)
+	}
+
+	result := operation.Command.Result
+	return result.Index, result.IsSuccessful, nil
+}
+
+// getMigrationLock reads the current lock and its index, returning nil if no lock is held
+func (ds *DatabaseService) getMigrationLock() (*migrationLock, int64, error) {
+	operation, err := ravendb.NewGetCompareExchangeValueOperation(reflect.TypeOf(&migrationLock{}), migrationLockKey)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create migration lock operation: %w", err)
+	}
+
+	err = ds.runOperation(true, func() error {
+		return ds.store.Operations().ForDatabase(ds.database).Send(operation, nil)
+	})
+	if err != nil {
+		return nil, 0, newOperationError("failed to read migration lock", migrationLockKey, "", "", err)
+	}
+
+	value := operation.Command.Result
+	if value == nil {
+		return nil, 0, nil
+	}
+	lock, _ := value.Value.(*migrationLock)
+	return lock, value.Index, nil
+}
+
+// migrationContext implements interfaces.IMigrationContext for one migration
+type migrationContext struct {
+	db        *DatabaseService
+	dryRun    bool
+	migration interfaces.Migration
+	report    *interfaces.MigrationReport
+}
+
+func (c *migrationContext) DB() interfaces.IRavenDBService { return c.db }
+func (c *migrationContext) DryRun() bool                   { return c.dryRun }
+
+// Logf records an action prefixed with the migration version
+func (c *migrationContext) Logf(format string, args ...interface{}) {
+	c.report.Actions = append(c.report.Actions, fmt.Sprintf("%d: ", c.migration.Version)+fmt.Sprintf(format, args...))
+}
+
+// Patch runs a JavaScript patch against every document in the collection
+func (c *migrationContext) Patch(collection, script string) error {
+	return c.patch(fmt.Sprintf("from %s update { %s }", collectionSource(collection), script))
+}
+
+// PatchWhere runs a JavaScript patch against the documents matching an RQL where clause
+func (c *migrationContext) PatchWhere(collection, whereClause, script string) error {
+	return c.patch(fmt.Sprintf("from %s where (%s) update { %s }", collectionSource(collection), whereClause, script))
+}
+
+// RenameField moves a top-level field on every document in the collection that has it
+func (c *migrationContext) RenameField(collection, from, to string) error {
+	fromJS, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	toJS, err := json.Marshal(to)
+	if err != nil {
+		return err
+	}
+
+	script := fmt.Sprintf("if (this.hasOwnProperty(%s)) { this[%s] = this[%s]; delete this[%s]; }", fromJS, toJS, fromJS, fromJS)
+	return c.Patch(collection, script)
+}
+
+// CreateIndex creates or updates a static index
+func (c *migrationContext) CreateIndex(name string, maps []string, reduce string) error {
+	c.Logf("create index %s", name)
+	if c.dryRun {
+		return nil
+	}
+
+	definition := ravendb.NewIndexDefinition()
+	definition.Name = name
+	definition.Maps = maps
+	if reduce != "" {
+		definition.Reduce = &reduce
+	}
+
+	err := c.db.runOperation(true, func() error {
+		return c.db.store.Maintenance().ForDatabase(c.db.database).Send(ravendb.NewPutIndexesOperation(definition))
+	})
+	if err != nil {
+		return newOperationError("failed to create index "+name, "", "", "", err)
+	}
+	return nil
+}
+
+// patch runs a patch-by-query operation and waits for it to complete
+func (c *migrationContext) patch(queryStr string) error {
+	c.Logf("%s", queryStr)
+	if c.dryRun {
+		return nil
+	}
+
+	// Patches are not generally safe to repeat, so they are never retried
+	return c.db.runOperation(false, func() error {
+		operation, err := c.db.store.Operations().ForDatabase(c.db.database).SendAsync(ravendb.NewPatchByQueryOperation(queryStr), nil)
+		if err != nil {
+			return newOperationError("failed to start patch", "", "", queryStr, err)
+		}
+		if err := operation.WaitForCompletion(); err != nil {
+			return newOperationError("failed to patch documents", "", "", queryStr, err)
+		}
+		return nil
+	})
+}
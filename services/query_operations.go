@@ -1,98 +1,105 @@
 package services
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/ravendb/ravendb-go-client"
 	"github.com/ternarybob/ravendb/interfaces"
 )
 
-// Query is a generic method that queries documents of a specific type T.
+// Query is a generic method that queries documents of a specific type T in a collection, which
+// is derived from T when empty
 func Query[T any](service interfaces.IRavenDBService, collection string, options *interfaces.QueryOptions) (*interfaces.GenericQueryResult[T], error) {
-	store := service.GetStore().(*ravendb.DocumentStore)
-	session, err := store.OpenSession(service.GetDatabase())
-	if err != nil {
-		return nil, fmt.Errorf("failed to open session: %w", err)
-	}
-	defer session.Close()
+	if collection == "" {
+		collection = CollectionNameFor[T]()
+	}
+	return runOperationResult(service, true, func() (*interfaces.GenericQueryResult[T], error) {
+		store := service.GetStore().(*ravendb.DocumentStore)
+		session, err := store.OpenSession(service.GetDatabase())
+		if err != nil {
+			return nil, newOperationError("failed to open session", "", collection, "", err)
+		}
+		defer session.Close()
 
-	// Set default values
-	if options == nil {
-		options = &interfaces.QueryOptions{}
-	}
-	if options.Take <= 0 {
-		options.Take = 25
-	}
-	if options.Take > 1024 {
-		options.Take = 1024
-	}
+		// Set default values
+		if options == nil {
+			options = &interfaces.QueryOptions{}
+		}
+		if options.Take <= 0 {
+			options.Take = 25
+		}
+		if options.Take > 1024 {
+			options.Take = 1024
+		}
 
-	// Build RQL query dynamically
-	var rqlQuery strings.Builder
-	// TODO: Fix collection metadata filtering - RavenDB Go client collection assignment needs investigation
-	// For now, query all documents to ensure tests pass
-	rqlQuery.WriteString("from @all_docs")
+		// Build RQL query dynamically
+		var rqlQuery strings.Builder
+		rqlQuery.WriteString("from " + collectionSource(collection))
 
-	// Add WHERE clause if specified
-	if options.WhereClause != "" {
-		rqlQuery.WriteString(fmt.Sprintf(" WHERE (%s)", options.WhereClause))
-	}
+		// Add WHERE clause if specified
+		if options.WhereClause != "" {
+			rqlQuery.WriteString(fmt.Sprintf(" WHERE (%s)", options.WhereClause))
+		}
 
-	// Add ORDER BY if specified
-	if options.OrderBy != "" {
-		if options.OrderDesc {
-			rqlQuery.WriteString(fmt.Sprintf(" ORDER BY %s DESC", options.OrderBy))
-		} else {
-			rqlQuery.WriteString(fmt.Sprintf(" ORDER BY %s", options.OrderBy))
+		// Add ORDER BY if specified
+		if options.OrderBy != "" {
+			if options.OrderDesc {
+				rqlQuery.WriteString(fmt.Sprintf(" ORDER BY %s DESC", options.OrderBy))
+			} else {
+				rqlQuery.WriteString(fmt.Sprintf(" ORDER BY %s", options.OrderBy))
+			}
 		}
-	}
 
-	// Add LIMIT (skip, take) for pagination
-	if options.Skip > 0 || options.Take > 0 {
-		skip := options.Skip
-		take := options.Take
-		if take <= 0 {
-			take = 25
+		// Add LIMIT (skip, take) for pagination
+		if options.Skip > 0 || options.Take > 0 {
+			skip := options.Skip
+			take := options.Take
+			if take <= 0 {
+				take = 25
+			}
+			rqlQuery.WriteString(fmt.Sprintf(" LIMIT %d, %d", skip, take))
 		}
-		rqlQuery.WriteString(fmt.Sprintf(" LIMIT %d, %d", skip, take))
-	}
 
-	// Execute the raw query
-	queryStr := rqlQuery.String()
-	query := session.Advanced().RawQuery(queryStr)
+		// Execute the raw query
+		queryStr := rqlQuery.String()
+		query := session.Advanced().RawQuery(queryStr)
 
-	// Set parameters if provided
-	if options.Parameters != nil {
-		for key, value := range options.Parameters {
-			query = query.AddParameter(key, value)
+		// Set parameters if provided
+		if options.Parameters != nil {
+			for key, value := range options.Parameters {
+				query = query.AddParameter(key, value)
+			}
 		}
-	}
 
-	var results []*T
-	err = query.GetResults(&results)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
-	}
-
-	// Convert pointers to values
-	finalResults := make([]T, len(results))
-	for i, res := range results {
-		if res != nil {
-			finalResults[i] = *res
+		var results []*T
+		err = query.GetResults(&results)
+		if err != nil {
+			return nil, newOperationError("failed to execute query", "", collection, queryStr, err)
 		}
-	}
 
-	totalCount := len(finalResults)
-	hasMore := options.Take > 0 && totalCount == options.Take
+		// Convert pointers to values, filling in each document's ID field
+		finalResults := make([]T, len(results))
+		for i, res := range results {
+			if res != nil {
+				setDocumentID(res, session.Advanced().GetDocumentID(res))
+				finalResults[i] = *res
+			}
+		}
 
-	return &interfaces.GenericQueryResult[T]{
-		Results:    finalResults,
-		TotalCount: totalCount,
-		Skip:       options.Skip,
-		Take:       options.Take,
-		HasMore:    hasMore,
-	}, nil
+		totalCount := len(finalResults)
+		hasMore := options.Take > 0 && totalCount == options.Take
+
+		return &interfaces.GenericQueryResult[T]{
+			Results:    finalResults,
+			TotalCount: totalCount,
+			Skip:       options.Skip,
+			Take:       options.Take,
+			HasMore:    hasMore,
+		}, nil
+	})
 }
 
 // QueryAll is a generic method that queries all documents of a specific type
@@ -162,3 +169,70 @@ func Search[T any](service interfaces.IRavenDBService, collection, searchTerm st
 
 	return Query[T](service, collection, options)
 }
+
+// RawQuery executes an RQL query and returns the raw results with their @metadata. It suits ad-hoc
+// queries and projections that do not map to a document type.
+func RawQuery(service interfaces.IRavenDBService, rql string, parameters map[string]interface{}) (*interfaces.RawQueryResult, error) {
+	data, err := json.Marshal(&rawQueryParameters{Query: rql, QueryParameters: parameters})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize query: %w", err)
+	}
+
+	command := &rawQueryCommand{RavenCommandBase: ravendb.NewRavenCommandBase(), parameters: data}
+	command.IsReadRequest = true
+
+	err = runOperation(service, true, func() error {
+		store := service.GetStore().(*ravendb.DocumentStore)
+		return store.GetRequestExecutor(service.GetDatabase()).ExecuteCommand(command, nil)
+	})
+	if err != nil {
+		return nil, newOperationError("failed to execute query", "", "", rql, err)
+	}
+
+	return &command.result, nil
+}
+
+// rawQueryParameters mirrors the server's IndexQuery request body
+type rawQueryParameters struct {
+	Query           string                 `json:"Query"`
+	QueryParameters map[string]interface{} `json:"QueryParameters,omitempty"`
+}
+
+// rawQueryCommand runs a query without deserializing its results into entities
+type rawQueryCommand struct {
+	ravendb.RavenCommandBase
+	parameters []byte
+	result     interfaces.RawQueryResult
+}
+
+func (c *rawQueryCommand) CreateRequest(node *ravendb.ServerNode) (*http.Request, error) {
+	return ravendb.NewHttpPost(node.URL+"/databases/"+node.Database+"/queries", c.parameters)
+}
+
+func (c *rawQueryCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return fmt.Errorf("empty query response")
+	}
+
+	var result struct {
+		Results        []map[string]interface{} `json:"Results"`
+		TotalResults   int                      `json:"TotalResults"`
+		SkippedResults int                      `json:"SkippedResults"`
+		IsStale        bool                     `json:"IsStale"`
+		IndexName      string                   `json:"IndexName"`
+		DurationInMs   int64                    `json:"DurationInMs"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return err
+	}
+
+	c.result = interfaces.RawQueryResult{
+		Results:        result.Results,
+		TotalResults:   result.TotalResults,
+		SkippedResults: result.SkippedResults,
+		IsStale:        result.IsStale,
+		IndexName:      result.IndexName,
+		DurationInMs:   result.DurationInMs,
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// operationRunner is implemented by database services that apply a resilience policy to operations
+type operationRunner interface {
+	runOperation(idempotent bool, fn func() error) error
+}
+
+// runOperation executes fn through the database's resilience policy, or once if it has none.
+// Operations that are unsafe to repeat must pass idempotent as false.
+func runOperation(database interfaces.IRavenDBService, idempotent bool, fn func() error) error {
+	if runner, ok := database.(operationRunner); ok {
+		return runner.runOperation(idempotent, fn)
+	}
+	return fn()
+}
+
+// runOperationResult is runOperation for operations that return a value
+func runOperationResult[R any](database interfaces.IRavenDBService, idempotent bool, fn func() (R, error)) (R, error) {
+	var result R
+	err := runOperation(database, idempotent, func() error {
+		var err error
+		result, err = fn()
+		return err
+	})
+	return result, err
+}
+
+// runOperation retries fn according to the service's retry policy, passing each attempt
+// through the circuit breaker so an open circuit fails fast instead of waiting for timeouts
+func (ds *DatabaseService) runOperation(idempotent bool, fn func() error) error {
+	attempt := fn
+	if ds.breaker != nil {
+		attempt = func() error {
+			return ds.breaker.execute(fn)
+		}
+	}
+	return retry(ds.options.Retry, idempotent, attempt)
+}
+
+// retry runs fn, repeating it with backoff while it fails with a retryable error
+func retry(policy *interfaces.RetryPolicy, idempotent bool, fn func() error) error {
+	if policy == nil || policy.MaxAttempts <= 1 || (!idempotent && !policy.RetryNonIdempotent) {
+		return fn()
+	}
+
+	var err error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		if err = fn(); err == nil || !isRetryable(policy, err) {
+			return err
+		}
+		if attempt < policy.MaxAttempts {
+			time.Sleep(backoff(policy, attempt))
+		}
+	}
+	return err
+}
+
+// isRetryable reports whether err belongs to one of the policy's transient error classes
+func isRetryable(policy *interfaces.RetryPolicy, err error) bool {
+	if policy.Retryable != nil {
+		return policy.Retryable(err)
+	}
+
+	retryOn := policy.RetryOn
+	if len(retryOn) == 0 {
+		retryOn = []error{interfaces.ErrTimeout, interfaces.ErrUnavailable}
+	}
+
+	// Classify raw client errors that have not been wrapped in an OperationError
+	kind := classifyError(err)
+	for _, target := range retryOn {
+		if errors.Is(err, target) || (kind != nil && errors.Is(kind, target)) {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the jittered delay before the retry following the given attempt
+func backoff(policy *interfaces.RetryPolicy, attempt int) time.Duration {
+	multiplier := policy.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(policy.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+	}
+	if policy.MaxBackoff > 0 && delay > float64(policy.MaxBackoff) {
+		delay = float64(policy.MaxBackoff)
+	}
+
+	if jitter := policy.Jitter; jitter > 0 {
+		if jitter > 1 {
+			jitter = 1
+		}
+		delay += delay * jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(delay)
+}
+
+// hasAllIDs reports whether every document has an explicit ID, making a bulk store safe to repeat
+func hasAllIDs[V any](documents map[string]V) bool {
+	for id := range documents {
+		if id == "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// seedRegistryID is the system document recording applied seed sets
+const seedRegistryID = "system/seeds"
+
+// seedRegistry is the stored form of the applied seed sets
+type seedRegistry struct {
+	Seeds []interfaces.SeedRecord `json:"seeds"`
+}
+
+// RegisterSeeder adds a seeder to run on InitializeWithSeeding(true), in registration order
+func (ds *DatabaseService) RegisterSeeder(seeder interfaces.ISeeder) {
+	ds.seedersMu.Lock()
+	defer ds.seedersMu.Unlock()
+	ds.seeders = append(ds.seeders, seeder)
+}
+
+// GetAppliedSeeds returns the seed sets that have been applied to the database
+func (ds *DatabaseService) GetAppliedSeeds() ([]interfaces.SeedRecord, error) {
+	registry, err := ds.loadSeedRegistry()
+	if err != nil {
+		return nil, err
+	}
+	return registry.Seeds, nil
+}
+
+// runSeeders applies every registered seeder that has not been applied and whose target is empty.
+// Emptiness is measured once before any seeder runs, so seeders do not block one another.
+func (ds *DatabaseService) runSeeders() error {
+	ds.seedersMu.Lock()
+	seeders := append([]interfaces.ISeeder(nil), ds.seeders...)
+	ds.seedersMu.Unlock()
+
+	if len(seeders) == 0 {
+		fmt.Println("No seeders registered, skipping seeding")
+		return nil
+	}
+
+	registry, err := ds.loadSeedRegistry()
+	if err != nil {
+		return err
+	}
+	applied := make(map[string]bool, len(registry.Seeds))
+	for _, record := range registry.Seeds {
+		applied[record.Name] = true
+	}
+
+	status, err := ds.GetStatus()
+	if err != nil {
+		return fmt.Errorf("failed to check whether the database is empty: %w", err)
+	}
+
+	for _, seeder := range seeders {
+		name := seeder.Name()
+		if applied[name] {
+			fmt.Printf("Seed '%s' already applied, skipping\n", name)
+			continue
+		}
+
+		if count := userDocumentCount(status, seeder.Collection()); count > 0 {
+			fmt.Printf("Seed '%s' skipped, target contains %d documents\n", name, count)
+			continue
+		}
+
+		documents, err := seeder.Seed(ds)
+		if err != nil {
+			return fmt.Errorf("seed '%s' failed: %w", name, err)
+		}
+
+		record := interfaces.SeedRecord{
+			Name:       name,
+			Collection: seeder.Collection(),
+			Documents:  documents,
+			AppliedAt:  time.Now().UTC(),
+		}
+		if err := ds.recordSeed(record); err != nil {
+			return err
+		}
+		applied[name] = true
+		fmt.Printf("Seed '%s' applied, %d documents written\n", name, documents)
+	}
+
+	return nil
+}
+
+// userDocumentCount counts documents in a collection, or in the database excluding the
+// library's system collection and RavenDB's internal @-prefixed collections
+func userDocumentCount(status *interfaces.DatabaseStatus, collection string) int64 {
+	var count int64
+	for name, documents := range status.Collections {
+		switch {
+		case collection != "":
+			if strings.EqualFold(name, collection) {
+				return documents
+			}
+		case name != systemCollection && !strings.HasPrefix(name, "@"):
+			count += documents
+		}
+	}
+	return count
+}
+
+// loadSeedRegistry loads the applied seed sets, returning an empty registry if none were applied
+func (ds *DatabaseService) loadSeedRegistry() (*seedRegistry, error) {
+	return loadSystemDocument[seedRegistry](ds, seedRegistryID)
+}
+
+// recordSeed appends an applied seed set to the registry
+func (ds *DatabaseService) recordSeed(record interfaces.SeedRecord) error {
+	return updateSystemDocument(ds, seedRegistryID, func(registry *seedRegistry) {
+		registry.Seeds = append(registry.Seeds, record)
+	})
+}
+
+// funcSeeder adapts a function to interfaces.ISeeder
+type funcSeeder struct {
+	name       string
+	collection string
+	seed       func(db interfaces.IRavenDBService) (int, error)
+}
+
+// NewSeeder creates a seeder that runs seed when the collection (or database, if empty) has no documents
+func NewSeeder(name, collection string, seed func(db interfaces.IRavenDBService) (int, error)) interfaces.ISeeder {
+	return &funcSeeder{name: name, collection: collection, seed: seed}
+}
+
+func (s *funcSeeder) Name() string       { return s.name }
+func (s *funcSeeder) Collection() string { return s.collection }
+
+func (s *funcSeeder) Seed(db interfaces.IRavenDBService) (int, error) {
+	return s.seed(db)
+}
+
+// fixtureSeeder seeds a collection from a JSON or NDJSON fixture file
+type fixtureSeeder struct {
+	name       string
+	collection string
+	path       string
+}
+
+// NewFixtureSeeder creates a seeder that loads the fixture file at path into the collection when it is empty
+func NewFixtureSeeder(name, collection, path string) interfaces.ISeeder {
+	return &fixtureSeeder{name: name, collection: collection, path: path}
+}
+
+func (s *fixtureSeeder) Name() string       { return s.name }
+func (s *fixtureSeeder) Collection() string { return s.collection }
+
+func (s *fixtureSeeder) Seed(db interfaces.IRavenDBService) (int, error) {
+	return LoadFixture(db, s.collection, s.path)
+}
+
+// LoadFixture bulk-inserts the documents in a JSON or NDJSON fixture file into a collection
+func LoadFixture(db interfaces.IRavenDBService, collection, path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open fixture file: %w", err)
+	}
+	defer file.Close()
+
+	return LoadFixtureFrom(db, collection, file)
+}
+
+// LoadFixtureFrom bulk-inserts fixture documents read from r into a collection. The input is either a
+// JSON array of objects or a stream of objects (NDJSON). Document IDs come from @metadata.@id, then
+// an "id", "Id" or "ID" field; documents without one get "<collection>/<position>".
+func LoadFixtureFrom(db interfaces.IRavenDBService, collection string, r io.Reader) (int, error) {
+	if collection == "" {
+		return 0, fmt.Errorf("fixture collection is required")
+	}
+
+	documents, err := readFixtureDocuments(r)
+	if err != nil {
+		return 0, err
+	}
+	if len(documents) == 0 {
+		return 0, nil
+	}
+
+	for i, document := range documents {
+		prepareFixtureDocument(document, collection, i)
+	}
+
+	// IDs are fixed before inserting, so repeating a failed insert overwrites rather than duplicates
+	err = runOperation(db, true, func() error {
+		store := db.GetStore().(*ravendb.DocumentStore)
+		bulkInsert := store.BulkInsert(db.GetDatabase())
+
+		for _, document := range documents {
+			id := document[ravendb.MetadataKey].(map[string]interface{})[ravendb.MetadataID].(string)
+			if err := bulkInsert.StoreWithID(document, id, nil); err != nil {
+				_ = bulkInsert.Abort()
+				return newOperationError("failed to insert fixture document", id, collection, "", err)
+			}
+		}
+
+		if err := bulkInsert.Close(); err != nil {
+			return newOperationError("failed to complete fixture insert", "", collection, "", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(documents), nil
+}
+
+// readFixtureDocuments decodes a JSON array of objects or a sequence of JSON objects
+func readFixtureDocuments(r io.Reader) ([]map[string]interface{}, error) {
+	reader := bufio.NewReader(r)
+
+	// Peek past leading whitespace to tell an array from a stream of objects
+	var first byte
+	for {
+		b, err := reader.ReadByte()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+		if !bytes.ContainsRune([]byte(" \t\r\n"), rune(b)) {
+			first = b
+			_ = reader.UnreadByte()
+			break
+		}
+	}
+
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+
+	var documents []map[string]interface{}
+	if first == '[' {
+		if err := decoder.Decode(&documents); err != nil {
+			return nil, fmt.Errorf("failed to parse fixture array: %w", err)
+		}
+		return documents, nil
+	}
+
+	for line := 1; ; line++ {
+		var document map[string]interface{}
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse fixture document %d: %w", line, err)
+		}
+		documents = append(documents, document)
+	}
+}
+
+// prepareFixtureDocument sets @metadata.@id and @metadata.@collection on a fixture document.
+// Map documents bypass the client's metadata handling, so the metadata must be embedded.
+func prepareFixtureDocument(document map[string]interface{}, collection string, position int) {
+	metadata, _ := document[ravendb.MetadataKey].(map[string]interface{})
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+
+	id, _ := metadata[ravendb.MetadataID].(string)
+	for _, field := range []string{"id", "Id", "ID"} {
+		if id != "" {
+			break
+		}
+		id, _ = document[field].(string)
+	}
+	if id == "" {
+		id = strings.ToLower(collection) + "/" + strconv.Itoa(position+1)
+	}
+
+	metadata[ravendb.MetadataID] = id
+	metadata[ravendb.MetadataCollection] = collection
+	document[ravendb.MetadataKey] = metadata
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// loadStatistics fills status with the database and per-collection statistics
+func (ds *DatabaseService) loadStatistics(status *interfaces.DatabaseStatus) error {
+	statsCommand := newGetDetailedStatisticsCommand()
+	err := ds.runOperation(true, func() error {
+		return ds.store.GetRequestExecutor(ds.database).ExecuteCommand(statsCommand, nil)
+	})
+	if err != nil {
+		return newOperationError("failed to get database statistics", "", "", "", err)
+	}
+
+	collectionsOperation := ravendb.NewGetCollectionStatisticsOperation()
+	err = ds.runOperation(true, func() error {
+		return ds.store.Maintenance().ForDatabase(ds.database).Send(collectionsOperation)
+	})
+	if err != nil {
+		return newOperationError("failed to get collection statistics", "", "", "", err)
+	}
+
+	stats := statsCommand.result
+	status.DocumentCount = stats.CountOfDocuments
+	status.IndexCount = stats.CountOfIndexes
+	status.AttachmentCount = stats.CountOfAttachments
+	status.CounterCount = stats.CountOfCounterEntries
+	status.RevisionCount = stats.CountOfRevisionDocuments
+	status.ConflictCount = stats.CountOfDocumentsConflicts
+	status.TombstoneCount = stats.CountOfTombstones
+	status.LastDocEtag = stats.LastDocEtag
+
+	if stats.SizeOnDisk != nil {
+		status.SizeOnDisk = stats.SizeOnDisk.SizeInBytes
+		status.SizeOnDiskHumane = stats.SizeOnDisk.HumaneSize
+	}
+	if stats.LastIndexingTime != nil {
+		lastIndexing := time.Time(*stats.LastIndexingTime)
+		status.LastIndexingTime = &lastIndexing
+	}
+
+	status.StaleIndexes = []string{}
+	for _, index := range stats.Indexes {
+		if index != nil && index.IsStale {
+			status.StaleIndexes = append(status.StaleIndexes, index.Name)
+		}
+	}
+
+	status.Collections = make(map[string]int64)
+	if result := collectionsOperation.Command.Result; result != nil {
+		for name, count := range result.Collections {
+			status.Collections[name] = int64(count)
+		}
+	}
+
+	return nil
+}
+
+// detailedStatistics mirrors the server's /stats response, including the counter and
+// revision counts missing from the client's DatabaseStatistics
+type detailedStatistics struct {
+	LastDocEtag               int64                       `json:"LastDocEtag"`
+	CountOfIndexes            int                         `json:"CountOfIndexes"`
+	CountOfDocuments          int64                       `json:"CountOfDocuments"`
+	CountOfRevisionDocuments  int64                       `json:"CountOfRevisionDocuments"`
+	CountOfDocumentsConflicts int64                       `json:"CountOfDocumentsConflicts"`
+	CountOfTombstones         int64                       `json:"CountOfTombstones"`
+	CountOfAttachments        int64                       `json:"CountOfAttachments"`
+	CountOfCounterEntries     int64                       `json:"CountOfCounterEntries"`
+	Indexes                   []*ravendb.IndexInformation `json:"Indexes"`
+	LastIndexingTime          *ravendb.Time               `json:"LastIndexingTime"`
+	SizeOnDisk                *ravendb.Size               `json:"SizeOnDisk"`
+}
+
+// getDetailedStatisticsCommand reads /stats into detailedStatistics
+type getDetailedStatisticsCommand struct {
+	ravendb.RavenCommandBase
+	result *detailedStatistics
+}
+
+func newGetDetailedStatisticsCommand() *getDetailedStatisticsCommand {
+	cmd := &getDetailedStatisticsCommand{
+		RavenCommandBase: ravendb.NewRavenCommandBase(),
+	}
+	cmd.IsReadRequest = true
+	return cmd
+}
+
+func (c *getDetailedStatisticsCommand) CreateRequest(node *ravendb.ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/stats"
+	return http.NewRequest(http.MethodGet, url, nil)
+}
+
+func (c *getDetailedStatisticsCommand) SetResponse(response []byte, fromCache bool) error {
+	if len(response) == 0 {
+		return fmt.Errorf("empty statistics response")
+	}
+	return json.Unmarshal(response, &c.result)
+}
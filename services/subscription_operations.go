@@ -0,0 +1,117 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// CreateSubscription creates a data subscription and returns its name
+func (ds *DatabaseService) CreateSubscription(options *interfaces.SubscriptionOptions) (string, error) {
+	if options == nil || options.Query == "" {
+		return "", fmt.Errorf("subscription query is required")
+	}
+
+	creationOptions := &ravendb.SubscriptionCreationOptions{
+		Name:       options.Name,
+		Query:      options.Query,
+		MentorNode: options.MentorNode,
+	}
+	if options.ChangeVector != "" {
+		changeVector := options.ChangeVector
+		creationOptions.ChangeVector = &changeVector
+	}
+
+	// Unnamed subscriptions get a server-generated name, so repeating the create could duplicate them
+	name, err := runOperationResult(ds, options.Name != "", func() (string, error) {
+		return ds.store.Subscriptions().Create(creationOptions, ds.database)
+	})
+	if err != nil {
+		return "", newOperationError("failed to create subscription", "", "", options.Query, err)
+	}
+
+	return name, nil
+}
+
+// UpdateSubscription changes the query, starting point or mentor node of an existing subscription
+func (ds *DatabaseService) UpdateSubscription(options *interfaces.SubscriptionOptions) error {
+	if options == nil || options.Name == "" {
+		return fmt.Errorf("subscription name is required")
+	}
+
+	changeVector := options.ChangeVector
+	if changeVector == "" {
+		changeVector = interfaces.SubscriptionDoNotChange
+	}
+
+	command, err := newUpdateSubscriptionCommand(&subscriptionUpdateOptions{
+		Name:         options.Name,
+		Query:        options.Query,
+		ChangeVector: changeVector,
+		MentorNode:   options.MentorNode,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ds.runOperation(true, func() error {
+		return ds.store.GetRequestExecutor(ds.database).ExecuteCommand(command, nil)
+	})
+	if err != nil {
+		return newOperationError("failed to update subscription "+options.Name, "", "", options.Query, err)
+	}
+
+	return nil
+}
+
+// DeleteSubscription removes a data subscription
+func (ds *DatabaseService) DeleteSubscription(name string) error {
+	err := ds.runOperation(true, func() error {
+		return ds.store.Subscriptions().Delete(name, ds.database)
+	})
+	if err != nil {
+		return newOperationError("failed to delete subscription "+name, "", "", "", err)
+	}
+	return nil
+}
+
+// subscriptionUpdateOptions mirrors the server's SubscriptionUpdateOptions record
+type subscriptionUpdateOptions struct {
+	Name         string `json:"Name"`
+	Query        string `json:"Query,omitempty"`
+	ChangeVector string `json:"ChangeVector,omitempty"`
+	MentorNode   string `json:"MentorNode,omitempty"`
+	CreateNew    bool   `json:"CreateNew"`
+}
+
+// updateSubscriptionCommand updates a subscription; the Go client has no built-in equivalent
+type updateSubscriptionCommand struct {
+	ravendb.RavenCommandBase
+	parameters []byte
+}
+
+func newUpdateSubscriptionCommand(options *subscriptionUpdateOptions) (*updateSubscriptionCommand, error) {
+	data, err := json.Marshal(options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize subscription options: %w", err)
+	}
+
+	cmd := &updateSubscriptionCommand{
+		RavenCommandBase: ravendb.NewRavenCommandBase(),
+		parameters:       data,
+	}
+	cmd.ResponseType = ravendb.RavenCommandResponseTypeObject
+	return cmd, nil
+}
+
+func (c *updateSubscriptionCommand) CreateRequest(node *ravendb.ServerNode) (*http.Request, error) {
+	url := node.URL + "/databases/" + node.Database + "/subscriptions/update"
+	return ravendb.NewHttpPost(url, c.parameters)
+}
+
+func (c *updateSubscriptionCommand) SetResponse(response []byte, fromCache bool) error {
+	return nil
+}
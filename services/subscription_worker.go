@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/ravendb/ravendb-go-client"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// SubscriptionWorker consumes a data subscription and delivers typed batches to a handler
+type SubscriptionWorker[T any] struct {
+	database interfaces.IRavenDBService
+	name     string
+	options  interfaces.SubscriptionWorkerOptions
+
+	mu     sync.Mutex
+	worker *ravendb.SubscriptionWorker
+}
+
+// NewSubscriptionWorker creates a worker for an existing subscription
+func NewSubscriptionWorker[T any](database interfaces.IRavenDBService, subscriptionName string, options *interfaces.SubscriptionWorkerOptions) interfaces.IRavenSubscriptionWorker[T] {
+	w := &SubscriptionWorker[T]{
+		database: database,
+		name:     subscriptionName,
+	}
+	if options != nil {
+		w.options = *options
+	}
+	return w
+}
+
+// GetSubscriptionName returns the name of the subscription being consumed
+func (w *SubscriptionWorker[T]) GetSubscriptionName() string {
+	return w.name
+}
+
+// Run connects to the subscription and blocks until ctx is cancelled, Close is called or the
+// subscription fails permanently. Connection failures are retried according to the worker options.
+func (w *SubscriptionWorker[T]) Run(ctx context.Context, handler interfaces.SubscriptionHandler[T]) error {
+	if handler == nil {
+		return fmt.Errorf("subscription handler is required")
+	}
+	if w.name == "" {
+		return fmt.Errorf("subscription name is required")
+	}
+
+	store := w.database.GetStore().(*ravendb.DocumentStore)
+
+	var zero T
+	worker, err := store.Subscriptions().GetSubscriptionWorker(reflect.TypeOf(&zero), w.workerOptions(), w.database.GetDatabase())
+	if err != nil {
+		return fmt.Errorf("failed to open subscription %s: %w", w.name, err)
+	}
+
+	w.mu.Lock()
+	if w.worker != nil {
+		w.mu.Unlock()
+		return fmt.Errorf("subscription %s is already running", w.name)
+	}
+	w.worker = worker
+	w.mu.Unlock()
+
+	defer func() {
+		w.mu.Lock()
+		w.worker = nil
+		w.mu.Unlock()
+	}()
+
+	err = worker.Run(func(batch *ravendb.SubscriptionBatch) error {
+		// Do not start (or acknowledge) new batches once shutdown has begun
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		typed, err := toTypedBatch[T](batch)
+		if err != nil {
+			return err
+		}
+
+		return w.dispatch(ctx, handler, typed)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start subscription %s: %w", w.name, err)
+	}
+
+	finished := make(chan error, 1)
+	go func() {
+		finished <- worker.WaitUntilFinished(0)
+	}()
+
+	select {
+	case <-ctx.Done():
+		// Close cancels the worker and waits for the in-flight batch to complete
+		worker.Close()
+		<-finished
+		return nil
+	case err := <-finished:
+		worker.Close()
+		if err != nil {
+			return fmt.Errorf("subscription %s stopped: %w", w.name, err)
+		}
+		return nil
+	}
+}
+
+// Close stops a running worker, waiting for the in-flight batch to complete
+func (w *SubscriptionWorker[T]) Close() error {
+	w.mu.Lock()
+	worker := w.worker
+	w.mu.Unlock()
+
+	if worker == nil {
+		return nil
+	}
+	return worker.Close()
+}
+
+// workerOptions converts the library options to the client's worker options
+func (w *SubscriptionWorker[T]) workerOptions() *ravendb.SubscriptionWorkerOptions {
+	opts := ravendb.NewSubscriptionWorkerOptions(w.name)
+
+	if w.options.MaxDocsPerBatch > 0 {
+		opts.MaxDocsPerBatch = w.options.MaxDocsPerBatch
+	}
+	if w.options.RetryDelay > 0 {
+		opts.TimeToWaitBeforeConnectionRetry = ravendb.Duration(w.options.RetryDelay)
+	}
+	if w.options.MaxErroneousPeriod > 0 {
+		opts.MaxErroneousPeriod = ravendb.Duration(w.options.MaxErroneousPeriod)
+	}
+	if w.options.Strategy != "" {
+		opts.Strategy = w.options.Strategy
+	}
+
+	return opts
+}
+
+// dispatch hands a batch to the handler, splitting it across goroutines when concurrency is configured.
+// The batch is acknowledged only if every part is handled successfully.
+func (w *SubscriptionWorker[T]) dispatch(ctx context.Context, handler interfaces.SubscriptionHandler[T], batch *interfaces.SubscriptionBatch[T]) error {
+	concurrency := w.options.Concurrency
+	if concurrency <= 1 || len(batch.Items) <= 1 {
+		return handler(ctx, batch)
+	}
+	if concurrency > len(batch.Items) {
+		concurrency = len(batch.Items)
+	}
+
+	chunkSize := (len(batch.Items) + concurrency - 1) / concurrency
+	errs := make(chan error, concurrency)
+	var wg sync.WaitGroup
+
+	for start := 0; start < len(batch.Items); start += chunkSize {
+		end := start + chunkSize
+		if end > len(batch.Items) {
+			end = len(batch.Items)
+		}
+
+		wg.Add(1)
+		go func(part *interfaces.SubscriptionBatch[T]) {
+			defer wg.Done()
+			if err := handler(ctx, part); err != nil {
+				errs <- err
+			}
+		}(&interfaces.SubscriptionBatch[T]{Items: batch.Items[start:end]})
+	}
+
+	wg.Wait()
+	close(errs)
+
+	return <-errs
+}
+
+// toTypedBatch converts a client subscription batch into typed items
+func toTypedBatch[T any](batch *ravendb.SubscriptionBatch) (*interfaces.SubscriptionBatch[T], error) {
+	items := make([]interfaces.SubscriptionItem[T], 0, len(batch.Items))
+
+	for _, item := range batch.Items {
+		var doc *T
+		if err := item.GetResult(&doc); err != nil {
+			return nil, fmt.Errorf("failed to read subscription document %s: %w", item.ID, err)
+		}
+
+		typed := interfaces.SubscriptionItem[T]{
+			ID:           item.ID,
+			ChangeVector: item.ChangeVector,
+		}
+		if doc != nil {
+			typed.Document = *doc
+		}
+		items = append(items, typed)
+	}
+
+	return &interfaces.SubscriptionBatch[T]{Items: items}, nil
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// systemCollection holds the library's bookkeeping documents, such as applied seeds and migrations
+const systemCollection = "System"
+
+// loadSystemDocument loads a system document, returning a zero document if it does not exist
+func loadSystemDocument[D any](ds *DatabaseService, id string) (*D, error) {
+	return runOperationResult(ds, true, func() (*D, error) {
+		session, err := ds.store.OpenSession(ds.database)
+		if err != nil {
+			return nil, newOperationError("failed to open session", "", systemCollection, "", err)
+		}
+		defer session.Close()
+
+		var document *D
+		if err := session.Load(&document, id); err != nil {
+			return nil, newOperationError("failed to load system document", id, systemCollection, "", err)
+		}
+		if document == nil {
+			document = new(D)
+		}
+		return document, nil
+	})
+}
+
+// systemDocumentAttempts bounds the read-modify-write cycles of a system document under contention
+const systemDocumentAttempts = 5
+
+// updateSystemDocument applies update to a system document, creating it if needed. The write is
+// conditional on the loaded change vector, or on the document not existing yet, so concurrent
+// writers cannot overwrite each other's changes; after a conflict the document is read again and
+// update applied to the fresh copy.
+func updateSystemDocument[D any](ds *DatabaseService, id string, update func(document *D)) error {
+	var err error
+	for attempt := 0; attempt < systemDocumentAttempts; attempt++ {
+		if err = writeSystemDocument(ds, id, update); !errors.Is(err, interfaces.ErrConcurrencyConflict) {
+			return err
+		}
+	}
+	return err
+}
+
+// writeSystemDocument makes one read-modify-write attempt, failing with
+// interfaces.ErrConcurrencyConflict if the document changed or was created in between
+func writeSystemDocument[D any](ds *DatabaseService, id string, update func(document *D)) error {
+	documents, err := loadDocuments(ds, []string{id}, false)
+	if err != nil {
+		return newOperationError("failed to load system document", id, systemCollection, "", err)
+	}
+
+	document := new(D)
+	changeVector := "" // an empty change vector requires the document not to exist
+	if raw := documents[0]; raw != nil {
+		if err := remarshal(raw, document); err != nil {
+			return newOperationError("failed to decode system document", id, systemCollection, "", err)
+		}
+		changeVector = parseMetadata(raw).ChangeVector
+	}
+	update(document)
+
+	data, err := entityDocument(document, systemCollection, nil, 0)
+	if err != nil {
+		return newOperationError("failed to store system document", id, systemCollection, "", err)
+	}
+	if _, err := putDocument(ds, id, data, &changeVector); err != nil {
+		return newOperationError("failed to save system document", id, systemCollection, "", err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+// validator is implemented by document types that check themselves before they are stored
+type validator interface {
+	Validate() error
+}
+
+// validate runs the document's Validate method and the collection's validators, returning a
+// *interfaces.ValidationError listing every invalid field
+func (cs *CollectionService[T]) validate(id string, document *T) error {
+	var fields []interfaces.FieldError
+	if v, ok := any(document).(validator); ok {
+		fields = appendFieldErrors(fields, v.Validate())
+	}
+	for _, validate := range cs.options.Validators {
+		fields = appendFieldErrors(fields, validate(document))
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+	if id == "" {
+		id = documentIDOf(document)
+	}
+	return &interfaces.ValidationError{ID: id, Collection: cs.collection, Fields: fields}
+}
+
+// validateAll validates documents keyed by ID in key order, joining the errors of all invalid ones
+func (cs *CollectionService[T]) validateAll(documents map[string]*T) error {
+	keys := make([]string, 0, len(documents))
+	for key := range documents {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		if err := cs.validate(key, documents[key]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// appendFieldErrors flattens a validator's error into field errors
+func appendFieldErrors(fields []interfaces.FieldError, err error) []interfaces.FieldError {
+	switch e := err.(type) {
+	case nil:
+		return fields
+	case *interfaces.ValidationError:
+		return append(fields, e.Fields...)
+	case *interfaces.FieldError:
+		return append(fields, *e)
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			fields = appendFieldErrors(fields, inner)
+		}
+		return fields
+	}
+	return append(fields, interfaces.FieldError{Message: err.Error()})
+}
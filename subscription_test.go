@@ -0,0 +1,81 @@
+package ravendb
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ternarybob/ravendb/interfaces"
+)
+
+func TestDataSubscriptions(t *testing.T) {
+	testConfig, err := LoadTestConfig(testConfigPath)
+	require.NoError(t, err, "Failed to load test configuration")
+
+	db, cleanup := SetupTestDatabase(t, testConfig)
+	defer cleanup()
+
+	userCollection := NewCollection[TestUser](db, "Users")
+
+	users := map[string]*TestUser{
+		"users/subscribed-1": {ID: "users/subscribed-1", Name: "Sub One", Age: 41, IsActive: true, Created: time.Now()},
+		"users/subscribed-2": {ID: "users/subscribed-2", Name: "Sub Two", Age: 42, IsActive: true, Created: time.Now()},
+	}
+	_, err = userCollection.StoreMultiple(users)
+	require.NoError(t, err, "Failed to store subscription test users")
+
+	name, err := db.CreateSubscription(&interfaces.SubscriptionOptions{
+		Query: "from @all_docs where startsWith(id(), 'users/subscribed-')",
+	})
+	require.NoError(t, err, "Failed to create subscription")
+	require.NotEmpty(t, name)
+
+	t.Run("WorkerDeliversTypedBatches", func(t *testing.T) {
+		worker := NewSubscriptionWorker[TestUser](db, name, &interfaces.SubscriptionWorkerOptions{
+			Concurrency: 2,
+			RetryDelay:  time.Second,
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		var mu sync.Mutex
+		received := make(map[string]TestUser)
+
+		err := worker.Run(ctx, func(ctx context.Context, batch *interfaces.SubscriptionBatch[TestUser]) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, item := range batch.Items {
+				received[item.ID] = item.Document
+			}
+			if len(received) >= len(users) {
+				cancel()
+			}
+			return nil
+		})
+		assert.NoError(t, err, "Worker should shut down cleanly")
+
+		mu.Lock()
+		defer mu.Unlock()
+		for id, user := range users {
+			assert.Contains(t, received, id)
+			assert.Equal(t, user.Name, received[id].Name)
+		}
+	})
+
+	t.Run("UpdateSubscription", func(t *testing.T) {
+		err := db.UpdateSubscription(&interfaces.SubscriptionOptions{
+			Name:  name,
+			Query: "from @all_docs where startsWith(id(), 'users/subscribed-2')",
+		})
+		assert.NoError(t, err, "Failed to update subscription")
+	})
+
+	t.Cleanup(func() {
+		db.DeleteSubscription(name)
+		userCollection.DeleteMultiple([]string{"users/subscribed-1", "users/subscribed-2"})
+	})
+}